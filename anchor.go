@@ -0,0 +1,148 @@
+package conv
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// AnchorStrategy controls how anchors for operation sections are generated
+type AnchorStrategy string
+
+const (
+	// AnchorCompact joins the lowercased method and path with punctuation removed,
+	// e.g. "GET /pets/{id}" becomes "getpetsid" (default)
+	AnchorCompact AnchorStrategy = "compact"
+	// AnchorOperationID uses the operationId, falling back to compact anchors for operations without one
+	AnchorOperationID AnchorStrategy = "operation-id"
+	// AnchorGitHub matches the slug GitHub generates for the rendered operation heading,
+	// e.g. "GET /pets/{id}" becomes "get-petsid"
+	AnchorGitHub AnchorStrategy = "github"
+)
+
+// endpointKey identifies an operation by method and path
+func endpointKey(e endpoint) string {
+	return e.method + " " + e.path
+}
+
+// endpointHeading returns the heading text rendered for an operation
+func endpointHeading(e endpoint) string {
+	heading := e.method + " " + e.path
+	if e.deprecated {
+		heading += " (deprecated)"
+	}
+	return heading
+}
+
+// assignAnchors computes the anchor of every operation in the order the operations appear in
+// the document body. Anchors that collide with an earlier operation receive a numeric suffix
+// and a warning.
+func (r *renderer) assignAnchors(endpoints []endpoint) {
+	r.anchors = make(map[string]string)
+	r.explicitAnchors = make(map[string]bool)
+	owners := make(map[string]string)
+
+	for _, e := range endpoints {
+		key := endpointKey(e)
+		if _, assigned := r.anchors[key]; assigned {
+			continue
+		}
+
+		anchor, explicit := r.makeEndpointAnchor(e)
+		if owner, taken := owners[anchor]; taken {
+			base := anchor
+			for i := 1; ; i++ {
+				anchor = fmt.Sprintf("%s-%d", base, i)
+				if _, taken := owners[anchor]; !taken {
+					break
+				}
+			}
+			explicit = true
+			r.warnings = append(r.warnings, Warning{
+				Code:      WarningAnchorCollision,
+				Message:   fmt.Sprintf("anchor %q for %s collides with %s, using %q", base, key, owner, anchor),
+				Pointer:   operationPointer(e.method, e.path),
+				Operation: key,
+			})
+		}
+
+		owners[anchor] = key
+		r.anchors[key] = anchor
+		r.explicitAnchors[key] = explicit
+	}
+}
+
+// makeEndpointAnchor returns the anchor for an operation according to the configured strategy,
+// and whether it must be emitted explicitly because the heading does not produce it
+func (r *renderer) makeEndpointAnchor(e endpoint) (string, bool) {
+	operationID := ""
+	if e.operation != nil {
+		operationID = e.operation.OperationId
+	}
+
+	if r.opts.AnchorFunc != nil {
+		if anchor := r.opts.AnchorFunc(e.method, e.path, operationID); anchor != "" {
+			return anchor, true
+		}
+	}
+
+	switch r.opts.Anchors {
+	case AnchorGitHub:
+		return makeGitHubAnchor(endpointHeading(e)), false
+	case AnchorOperationID:
+		if operationID != "" {
+			return operationID, true
+		}
+	}
+
+	return makeAnchor(e.method, e.path), false
+}
+
+// anchor returns the anchor assigned to an operation
+func (r *renderer) anchor(e endpoint) string {
+	if anchor, ok := r.anchors[endpointKey(e)]; ok {
+		return anchor
+	}
+	return makeAnchor(e.method, e.path)
+}
+
+func makeAnchor(method, path string) string {
+	combined := method + " " + path
+	combined = strings.ToLower(combined)
+
+	reg := regexp.MustCompile(`[^a-z0-9]+`)
+	combined = reg.ReplaceAllString(combined, "")
+
+	return combined
+}
+
+// makeGitHubAnchor creates the anchor GitHub generates for a heading: lowercased, punctuation
+// removed and spaces replaced with hyphens
+func makeGitHubAnchor(heading string) string {
+	var anchor strings.Builder
+
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.In(r, unicode.M, unicode.Pc) || r == '-':
+			anchor.WriteRune(r)
+		case r == ' ':
+			anchor.WriteRune('-')
+		}
+	}
+
+	return anchor.String()
+}
+
+// makeTagAnchor creates an anchor for a tag section heading
+func makeTagAnchor(tag string) string {
+	return makeGitHubAnchor(tag)
+}
+
+// makeSchemaAnchor creates an anchor for a schema name
+func makeSchemaAnchor(schemaName string) string {
+	anchor := strings.ToLower(schemaName)
+	reg := regexp.MustCompile(`[^a-z0-9]+`)
+	anchor = reg.ReplaceAllString(anchor, "-")
+	return strings.Trim(anchor, "-")
+}
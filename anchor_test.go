@@ -0,0 +1,146 @@
+package conv_test
+
+import (
+	"strings"
+	"testing"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertAnchorStrategies(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      summary: Get pet
+  /petsid:
+    get:
+      summary: Get pets id`
+
+	for _, test := range []struct {
+		name         string
+		opts         conv.ConvertOptions
+		wantMd       []string
+		notWantMd    []string
+		wantWarnings []string
+		wantErr      string
+	}{
+		{
+			name: "compact anchors with collision",
+			opts: conv.ConvertOptions{
+				Title: "Test API",
+			},
+			wantMd: []string{
+				"GET [/pets/{id}](#getpetsid) | Get pet",
+				"GET [/petsid](#getpetsid-1) | Get pets id",
+				"<a id=\"getpetsid-1\"></a>\n\n## GET /petsid\n\n",
+				"## GET /pets/{id}\n\nOperation ID: `getPet`\n\nGet pet\n\n",
+			},
+			notWantMd: []string{"<a id=\"getpetsid\"></a>"},
+			wantWarnings: []string{
+				`anchor "getpetsid" for GET /petsid collides with GET /pets/{id}, using "getpetsid-1"`,
+			},
+		},
+		{
+			name: "operationId anchors",
+			opts: conv.ConvertOptions{
+				Title:   "Test API",
+				Anchors: conv.AnchorOperationID,
+			},
+			wantMd: []string{
+				"GET [/pets/{id}](#getPet) | Get pet",
+				"GET [/petsid](#getpetsid) | Get pets id",
+				"<a id=\"getPet\"></a>\n\n## GET /pets/{id}\n\n",
+			},
+			notWantMd: []string{"<a id=\"getpetsid\"></a>"},
+		},
+		{
+			name: "github anchors",
+			opts: conv.ConvertOptions{
+				Title:   "Test API",
+				Anchors: conv.AnchorGitHub,
+			},
+			wantMd: []string{
+				"GET [/pets/{id}](#get-petsid) | Get pet",
+				"GET [/petsid](#get-petsid-1) | Get pets id",
+			},
+			notWantMd: []string{"<a id=\"get-petsid\"></a>"},
+			wantWarnings: []string{
+				`anchor "get-petsid" for GET /petsid collides with GET /pets/{id}, using "get-petsid-1"`,
+			},
+		},
+		{
+			name: "custom anchor func",
+			opts: conv.ConvertOptions{
+				Title: "Test API",
+				AnchorFunc: func(method, path, operationID string) string {
+					if operationID == "" {
+						return ""
+					}
+					return "op-" + strings.ToLower(operationID)
+				},
+			},
+			wantMd: []string{
+				"GET [/pets/{id}](#op-getpet) | Get pet",
+				"<a id=\"op-getpet\"></a>\n\n## GET /pets/{id}\n\n",
+				"GET [/petsid](#getpetsid) | Get pets id",
+			},
+		},
+		{
+			name: "unsupported strategy",
+			opts: conv.ConvertOptions{
+				Title:   "Test API",
+				Anchors: "random",
+			},
+			wantErr: "unsupported anchor strategy: random",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(spec), test.opts)
+
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			md := string(result.Markdown)
+
+			for _, want := range test.wantMd {
+				assert.Contains(t, md, want)
+			}
+			for _, notWant := range test.notWantMd {
+				assert.NotContains(t, md, notWant)
+			}
+			var warnings []string
+			for _, warning := range result.Warnings {
+				warnings = append(warnings, warning.Message)
+			}
+			assert.Equal(t, test.wantWarnings, warnings)
+		})
+	}
+}
+
+func TestConvertGitHubAnchorDeprecatedHeading(t *testing.T) {
+	result, err := conv.Convert([]byte(`openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /v1/pets.list:
+    get:
+      summary: List pets
+      deprecated: true`), conv.ConvertOptions{
+		Title:   "Test API",
+		Anchors: conv.AnchorGitHub,
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(result.Markdown), "GET [/v1/pets.list](#get-v1petslist-deprecated) | List pets *(deprecated)*")
+}
@@ -0,0 +1,112 @@
+package conv
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+// defaultAudienceExtension is the vendor extension read when ConvertOptions.AudienceExtension is empty
+const defaultAudienceExtension = "x-audience"
+
+// isExcludedAudience reports whether the audience extension in extensions names one of the
+// audiences excluded by opts. The extension may hold a single value or a list of values.
+func isExcludedAudience(opts ConvertOptions, extensions *orderedmap.Map[string, *yaml.Node]) bool {
+	if len(opts.ExcludeAudiences) == 0 || extensions == nil {
+		return false
+	}
+
+	key := opts.AudienceExtension
+	if key == "" {
+		key = defaultAudienceExtension
+	}
+
+	node := extensions.GetOrZero(key)
+	if node == nil {
+		return false
+	}
+
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return false
+	}
+
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+
+	for _, v := range values {
+		if slices.Contains(opts.ExcludeAudiences, fmt.Sprintf("%v", v)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filterAudience drops operations whose audience is excluded
+func filterAudience(endpoints []endpoint, opts ConvertOptions) []endpoint {
+	if len(opts.ExcludeAudiences) == 0 {
+		return endpoints
+	}
+
+	var filtered []endpoint
+	for _, e := range endpoints {
+		if e.operation != nil && isExcludedAudience(opts, e.operation.Extensions) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+
+	return filtered
+}
+
+// isHiddenSchema reports whether a schema (typically a property) belongs to an excluded audience
+func (r *renderer) isHiddenSchema(schema *base.Schema) bool {
+	return schema != nil && isExcludedAudience(r.opts, schema.Extensions)
+}
+
+// pruneExample removes properties belonging to excluded audiences from a decoded JSON example,
+// following the schema that describes it. Maps are modified in place.
+func (r *renderer) pruneExample(value interface{}, schemaProxy *base.SchemaProxy) {
+	if len(r.opts.ExcludeAudiences) == 0 || schemaProxy == nil {
+		return
+	}
+
+	schema := schemaProxy.Schema()
+	if schema == nil {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		mergedProps, _ := mergeAllOfProperties(schema)
+		if mergedProps != nil {
+			for pair := mergedProps.First(); pair != nil; pair = pair.Next() {
+				propProxy := pair.Value()
+				if _, ok := v[pair.Key()]; !ok || propProxy == nil {
+					continue
+				}
+				if r.isHiddenSchema(propProxy.Schema()) {
+					delete(v, pair.Key())
+					continue
+				}
+				r.pruneExample(v[pair.Key()], propProxy)
+			}
+		}
+
+		for _, variantProxy := range schema.OneOf {
+			r.pruneExample(v, variantProxy)
+		}
+	case []interface{}:
+		if schema.Items != nil && schema.Items.IsA() {
+			for _, item := range v {
+				r.pruneExample(item, schema.Items.A)
+			}
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package conv_test
+
+import (
+	"strings"
+	"testing"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertExcludeAudiences(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      summary: Get user
+      parameters:
+        - name: id
+          in: path
+          required: true
+          description: User ID
+          schema:
+            type: string
+        - name: debug
+          in: query
+          description: Include debug details
+          x-audience: internal
+          schema:
+            type: boolean
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+              example:
+                id: "42"
+                name: Alice
+                riskScore: 7
+        '418':
+          description: Internal diagnostics
+          x-audience: [internal, ops]
+    put:
+      summary: Update user
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+      responses:
+        '200':
+          description: Updated
+  /users/{id}/audit:
+    get:
+      summary: Audit trail
+      x-visibility: internal
+      responses:
+        '200':
+          description: Audit entries
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id:
+          type: string
+          description: User ID
+        name:
+          type: string
+          description: Display name
+        riskScore:
+          type: integer
+          description: Internal fraud score
+          x-audience: internal`
+
+	for _, test := range []struct {
+		name          string
+		opts          conv.ConvertOptions
+		wantMd        []string
+		notWantMd     []string
+		wantEndpoints int
+	}{
+		{
+			name: "everything rendered without exclusions",
+			opts: conv.ConvertOptions{
+				Title: "Test API",
+			},
+			wantMd: []string{
+				"`debug` *(boolean)*",
+				"#### 418 Response",
+				"`riskScore` *(integer)* Internal fraud score",
+				`"riskScore": 7`,
+			},
+			wantEndpoints: 3,
+		},
+		{
+			name: "internal audience excluded",
+			opts: conv.ConvertOptions{
+				Title:            "Test API",
+				ExcludeAudiences: []string{"internal"},
+			},
+			wantMd: []string{
+				"`id` *(string, required)* User ID",
+				"`name` *(string)* Display name",
+				`"name": "Alice"`,
+				"## GET /users/{id}/audit",
+			},
+			notWantMd: []string{
+				"debug",
+				"#### 418 Response",
+				"riskScore",
+			},
+			wantEndpoints: 3,
+		},
+		{
+			name: "custom extension key",
+			opts: conv.ConvertOptions{
+				Title:             "Test API",
+				ExcludeAudiences:  []string{"internal"},
+				AudienceExtension: "x-visibility",
+			},
+			wantMd: []string{
+				"riskScore",
+				"#### 418 Response",
+			},
+			notWantMd: []string{
+				"/users/{id}/audit",
+			},
+			wantEndpoints: 2,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(spec), test.opts)
+			require.NoError(t, err)
+
+			md := string(result.Markdown)
+			for _, want := range test.wantMd {
+				assert.Contains(t, md, want)
+			}
+			for _, notWant := range test.notWantMd {
+				assert.NotContains(t, md, notWant)
+			}
+			assert.Equal(t, test.wantEndpoints, result.EndpointCount)
+		})
+	}
+}
+
+func TestConvertExcludeAudienceSharedSchemas(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /keys:
+    get:
+      summary: List keys
+      responses:
+        '200':
+          description: Keys
+          x-audience: internal
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Secret'
+    post:
+      summary: Create key
+      requestBody:
+        x-audience: internal
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Secret'
+      responses:
+        '204':
+          description: Created
+  /keys/{id}:
+    get:
+      summary: Get key
+      responses:
+        '200':
+          description: Key
+          x-audience: internal
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Secret'
+components:
+  schemas:
+    Secret:
+      type: object
+      properties:
+        value:
+          type: string
+          description: Secret value`
+
+	for _, test := range []struct {
+		name             string
+		excludeAudiences []string
+		wantMd           []string
+		notWantMd        []string
+		wantResponses    int
+	}{
+		{
+			name:          "shared without exclusions",
+			wantMd:        []string{"## Shared Schema Definitions", "### Secret"},
+			wantResponses: 3,
+		},
+		{
+			name:             "hidden responses and request bodies do not share schemas",
+			excludeAudiences: []string{"internal"},
+			wantMd:           []string{"#### 204 Response"},
+			notWantMd:        []string{"Shared Schema Definitions", "Secret"},
+			// Only POST /keys keeps a visible response, so GET operations write no empty heading
+			wantResponses: 1,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(spec), conv.ConvertOptions{
+				Title:               "Test API",
+				EnableSharedSchemas: true,
+				DisableCodeSamples:  true,
+				ExcludeAudiences:    test.excludeAudiences,
+			})
+			require.NoError(t, err)
+
+			md := string(result.Markdown)
+			for _, want := range test.wantMd {
+				assert.Contains(t, md, want)
+			}
+			for _, notWant := range test.notWantMd {
+				assert.NotContains(t, md, notWant)
+			}
+			assert.Equal(t, test.wantResponses, strings.Count(md, "### Responses"))
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	// indexFile is the name of the index page written next to the converted specs
+	indexFile = "index.md"
+	// defaultIndexTitle is used for the index page when no title is given
+	defaultIndexTitle = "API Documentation"
+)
+
+// specInfo is the part of a spec's info object listed on the index page
+type specInfo struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+// isBatch reports whether the job converts several specs, either from a list of inputs or
+// from a glob pattern
+func (j jobConfig) isBatch() bool {
+	return len(j.Inputs) > 0 || hasGlobMeta(j.Input)
+}
+
+// specFiles expands the job inputs and glob patterns into a list of spec files
+func (j jobConfig) specFiles() ([]string, error) {
+	patterns := j.Inputs
+	if len(patterns) == 0 {
+		patterns = []string{j.Input}
+	}
+
+	var files []string
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		if pattern == stdio {
+			return nil, fmt.Errorf("stdin cannot be used when converting several specs")
+		}
+
+		matches := []string{pattern}
+		if hasGlobMeta(pattern) {
+			var err error
+			if matches, err = filepath.Glob(pattern); err != nil {
+				return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no specs match %s", pattern)
+			}
+		}
+
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				files = append(files, match)
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// runBatch converts every spec of the job into its own markdown file in the output directory
+// and writes an index page linking them. A failing spec is reported and skipped so the rest of
+// the docs folder is still refreshed.
+func runBatch(job jobConfig, check bool) error {
+	if job.Inject != "" {
+		return fmt.Errorf("inject cannot be used when converting several specs")
+	}
+
+	outDir := job.Output
+	if outDir == stdio {
+		return fmt.Errorf("stdout cannot be used when converting several specs")
+	}
+	if outDir == "" {
+		outDir = "."
+	}
+
+	files, err := job.specFiles()
+	if err != nil {
+		return err
+	}
+
+	names := outputNames(files)
+
+	if !check {
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			return fmt.Errorf("creating %s: %w", outDir, err)
+		}
+	}
+
+	var index []indexEntry
+	failed, stale := 0, 0
+	for _, file := range files {
+		spec := job
+		spec.Inputs = nil
+		spec.Input = file
+		spec.Output = filepath.Join(outDir, names[file]+".md")
+
+		info, err := readSpecInfo(file)
+		if err == nil {
+			spec.Title = info.Title
+			err = runJob(spec, check)
+		}
+		switch exitCode(err) {
+		case exitFindings:
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			stale++
+		case exitError:
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			failed++
+			continue
+		}
+
+		index = append(index, indexEntry{info: info, link: filepath.Base(spec.Output)})
+	}
+
+	title := job.Title
+	if title == "" {
+		title = defaultIndexTitle
+	}
+
+	indexPath := filepath.Join(outDir, indexFile)
+	err = writeOutput(indexPath, strings.Join(files, ", "), renderIndex(title, job.Description, index), check)
+	switch exitCode(err) {
+	case exitFindings:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		stale++
+	case exitError:
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d specs failed", failed, len(files))
+	}
+	if stale > 0 {
+		return findings("%d documents in %s are out of date", stale, outDir)
+	}
+	return nil
+}
+
+// indexEntry is one converted spec listed on the index page
+type indexEntry struct {
+	info specInfo
+	link string
+}
+
+// renderIndex renders the index page as a table of spec titles and versions
+func renderIndex(title, description string, entries []indexEntry) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "# %s\n\n", title)
+	if description != "" {
+		fmt.Fprintf(&buf, "%s\n\n", description)
+	}
+
+	buf.WriteString("| API | Version |\n")
+	buf.WriteString("|-----|---------|\n")
+	for _, entry := range entries {
+		fmt.Fprintf(&buf, "| [%s](%s) | %s |\n", escapeTableCell(entry.info.Title), entry.link, escapeTableCell(entry.info.Version))
+	}
+
+	return buf.Bytes()
+}
+
+// readSpecInfo reads the title and version from a spec's info object. The title falls back to
+// the file name when the spec does not declare one.
+func readSpecInfo(path string) (specInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return specInfo{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var doc struct {
+		Info specInfo `yaml:"info"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return specInfo{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	if doc.Info.Title == "" {
+		doc.Info.Title = specBaseName(path)
+	}
+	return doc.Info, nil
+}
+
+// outputNames returns the name of the markdown file written for each spec. Specs are named
+// after their file; when names collide, as with services/*/openapi.yaml, parent directories
+// are prepended until every name is unique and none is taken by the index page. Specs that
+// still collide are numbered.
+func outputNames(files []string) map[string]string {
+	depth := make(map[string]int, len(files))
+	for deeper := true; deeper; {
+		groups := make(map[string][]string)
+		for _, file := range files {
+			name := outputName(file, depth[file])
+			groups[name] = append(groups[name], file)
+		}
+
+		deeper = false
+		for name, group := range groups {
+			if len(group) == 1 && name+".md" != indexFile {
+				continue
+			}
+			for _, file := range group {
+				if depth[file] < len(parentDirs(file)) {
+					depth[file]++
+					deeper = true
+				}
+			}
+		}
+	}
+
+	names := make(map[string]string, len(files))
+	taken := map[string]bool{strings.TrimSuffix(indexFile, ".md"): true}
+	for _, file := range files {
+		base := outputName(file, depth[file])
+		name := base
+		for i := 2; taken[name]; i++ {
+			name = fmt.Sprintf("%s-%d", base, i)
+		}
+		taken[name] = true
+		names[file] = name
+	}
+	return names
+}
+
+// outputName names a spec after its file, prefixed with depth of its parent directories
+func outputName(file string, depth int) string {
+	dirs := parentDirs(file)
+	parts := append(slices.Clone(dirs[len(dirs)-depth:]), specBaseName(file))
+	return strings.Join(parts, "-")
+}
+
+// parentDirs returns the named directories of a path, outermost first, leaving out "." and ".."
+func parentDirs(file string) []string {
+	var dirs []string
+	for _, dir := range strings.Split(filepath.ToSlash(filepath.Dir(filepath.Clean(file))), "/") {
+		if dir != "" && dir != "." && dir != ".." {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// specBaseName returns the file name of a spec without its directory and extension
+func specBaseName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// hasGlobMeta reports whether a path contains glob pattern characters
+func hasGlobMeta(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// escapeTableCell escapes characters that would break a markdown table cell
+func escapeTableCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
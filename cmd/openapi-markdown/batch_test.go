@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputNames(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		files []string
+		want  map[string]string
+	}{
+		{
+			name:  "unique file names",
+			files: []string{"specs/users.yaml", "specs/orders.json"},
+			want:  map[string]string{"specs/users.yaml": "users", "specs/orders.json": "orders"},
+		},
+		{
+			name:  "same file name in different directories",
+			files: []string{"services/users/openapi.yaml", "services/orders/openapi.yaml"},
+			want: map[string]string{
+				"services/users/openapi.yaml":  "users-openapi",
+				"services/orders/openapi.yaml": "orders-openapi",
+			},
+		},
+		{
+			name:  "collision resolved deeper",
+			files: []string{"a/v1/api.yaml", "b/v1/api.yaml", "c/api.yaml"},
+			want: map[string]string{
+				"a/v1/api.yaml": "a-v1-api",
+				"b/v1/api.yaml": "b-v1-api",
+				"c/api.yaml":    "c-api",
+			},
+		},
+		{
+			name:  "spec named index",
+			files: []string{"specs/index.yaml"},
+			want:  map[string]string{"specs/index.yaml": "specs-index"},
+		},
+		{
+			name:  "same name in the same directory",
+			files: []string{"api.yaml", "api.json"},
+			want:  map[string]string{"api.yaml": "api", "api.json": "api-2"},
+		},
+		{
+			name:  "index without parent directory",
+			files: []string{"index.yaml"},
+			want:  map[string]string{"index.yaml": "index-2"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, outputNames(test.files))
+		})
+	}
+}
+
+func TestRunBatchWarnings(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"pets", "users"} {
+		spec := "openapi: 3.0.0\ninfo:\n  title: " + name + "\n  version: 1.0.0\npaths:\n  /" + name + ":\n    get:\n      responses:\n        '204':\n          description: Done\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(spec), 0o644))
+	}
+	pets, users := filepath.Join(dir, "pets.yaml"), filepath.Join(dir, "users.yaml")
+
+	stderr := captureOutput(t, &os.Stderr, func() {
+		require.NoError(t, runBatch(jobConfig{Inputs: []string{pets, users}, Output: filepath.Join(dir, "docs")}, false))
+	})
+
+	assert.Contains(t, stderr, pets+": Warning: /paths/~1pets/get: GET /pets has no description or summary\n")
+	assert.Contains(t, stderr, users+": Warning: /paths/~1users/get: GET /users has no description or summary\n")
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// checkOutput compares generated content against the file at path and returns a unified diff
+// describing how to bring the file up to date. An empty diff means the file is current.
+func checkOutput(path string, generated []byte) (string, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	if err != nil {
+		// A missing file diffs as if every generated line were added
+		existing = nil
+	}
+
+	if bytes.Equal(existing, generated) {
+		return "", nil
+	}
+
+	return unifiedDiff(existing, generated, path, path+" (generated)")
+}
+
+// unifiedDiff returns a unified diff turning a into b
+func unifiedDiff(a, b []byte, fromFile, toFile string) (string, error) {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(a),
+		B:        splitLines(b),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to diff %s: %w", fromFile, err)
+	}
+
+	return diff, nil
+}
+
+// noNewlineMarker follows a final line without a newline, as in diff(1) output
+const noNewlineMarker = "\\ No newline at end of file\n"
+
+// splitLines splits content into newline-terminated lines for diffing. Unlike
+// difflib.SplitLines it reports no lines for empty content and no trailing empty line for
+// content ending in a newline. A final line without a newline carries noNewlineMarker, so
+// content that differs only in its trailing newline still produces a diff.
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n" + noNewlineMarker
+	return lines
+}
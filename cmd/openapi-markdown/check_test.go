@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckOutput(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		existing *string
+		want     string
+	}{
+		{
+			name:     "up to date",
+			existing: ptr("# API\n\nline\n"),
+		},
+		{
+			name:     "stale",
+			existing: ptr("# API\n\nold\n"),
+			want:     "--- {path}\n+++ {path} (generated)\n@@ -1,3 +1,3 @@\n # API\n \n-old\n+line\n",
+		},
+		{
+			name:     "missing trailing newline",
+			existing: ptr("# API\n\nline"),
+			want:     "--- {path}\n+++ {path} (generated)\n@@ -1,3 +1,3 @@\n # API\n \n-line\n\\ No newline at end of file\n+line\n",
+		},
+		{
+			name: "missing file",
+			want: "--- {path}\n+++ {path} (generated)\n@@ -0,0 +1,3 @@\n+# API\n+\n+line\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "api.md")
+			if test.existing != nil {
+				require.NoError(t, os.WriteFile(path, []byte(*test.existing), 0o644))
+			}
+
+			diff, err := checkOutput(path, []byte("# API\n\nline\n"))
+			require.NoError(t, err)
+			assert.Equal(t, strings.ReplaceAll(test.want, "{path}", path), diff)
+		})
+	}
+}
+
+func TestWriteOutputCheck(t *testing.T) {
+	out := useStdio(t, "")
+	path := filepath.Join(t.TempDir(), "api.md")
+	require.NoError(t, os.WriteFile(path, []byte("# API\n\nold\n"), 0o644))
+
+	err := writeOutput(path, "openapi.yaml", []byte("# API\n\nline\n"), true)
+	require.Error(t, err)
+	assert.Equal(t, exitFindings, exitCode(err))
+	assert.Equal(t, "--- "+path+"\n+++ "+path+" (generated)\n@@ -1,3 +1,3 @@\n # API\n \n-old\n+line\n", out.String())
+
+	out.Reset()
+	captureOutput(t, &os.Stderr, func() {
+		require.NoError(t, writeOutput(path, "openapi.yaml", []byte("# API\n\nold\n"), true))
+	})
+	assert.Empty(t, out.String())
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		a    string
+		b    string
+		want string
+	}{
+		{
+			name: "identical",
+			a:    "a\nb\n",
+			b:    "a\nb\n",
+		},
+		{
+			name: "changed line",
+			a:    "a\nb\nc\n",
+			b:    "a\nB\nc\n",
+			want: "--- old.md\n+++ new.md\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name: "added to empty",
+			b:    "a\n",
+			want: "--- old.md\n+++ new.md\n@@ -0,0 +1 @@\n+a\n",
+		},
+		{
+			name: "newline added at end",
+			a:    "a\nb",
+			b:    "a\nb\n",
+			want: "--- old.md\n+++ new.md\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+		{
+			name: "newline removed at end",
+			a:    "a\n",
+			b:    "a",
+			want: "--- old.md\n+++ new.md\n@@ -1 +1 @@\n-a\n+a\n\\ No newline at end of file\n",
+		},
+		{
+			name: "removed everything",
+			a:    "a\n",
+			want: "--- old.md\n+++ new.md\n@@ -1 +0,0 @@\n-a\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			diff, err := unifiedDiff([]byte(test.a), []byte(test.b), "old.md", "new.md")
+			require.NoError(t, err)
+			assert.Equal(t, test.want, diff)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+	"go.yaml.in/yaml/v4"
+)
+
+// defaultConfigFile is loaded from the working directory when -config is not given
+const defaultConfigFile = "openapi-markdown.yaml"
+
+// config is the contents of an openapi-markdown.yaml file. Settings at the top level apply to
+// every job and can be overridden per job.
+type config struct {
+	jobConfig `yaml:",inline"`
+	Jobs      []jobConfig `yaml:"jobs"`
+}
+
+// jobConfig describes a single conversion. Pointer fields distinguish "not set" from the zero
+// value so that job settings and flags only override what they specify.
+type jobConfig struct {
+	Input               string            `yaml:"input"`
+	Inputs              []string          `yaml:"inputs"`
+	Output              string            `yaml:"output"`
+	Inject              string            `yaml:"inject"`
+	Title               string            `yaml:"title"`
+	Description         string            `yaml:"description"`
+	SharedSchemas       *bool             `yaml:"sharedSchemas"`
+	TOC                 string            `yaml:"toc"`
+	HeadingOffset       *int              `yaml:"headingOffset"`
+	MaxExamples         *int              `yaml:"maxExamples"`
+	Anchors             string            `yaml:"anchors"`
+	Deprecated          string            `yaml:"deprecated"`
+	AudienceExtension   string            `yaml:"audienceExtension"`
+	ExcludeAudiences    []string          `yaml:"excludeAudiences"`
+	Include             filterConfig      `yaml:"include"`
+	Exclude             filterConfig      `yaml:"exclude"`
+	Strict              *bool             `yaml:"strict"`
+	WarningsAsErrors    *bool             `yaml:"warningsAsErrors"`
+	LintRules           map[string]string `yaml:"lintRules"`
+	CodeSamples         *bool             `yaml:"codeSamples"`
+	CodeSampleLanguages []string          `yaml:"codeSampleLanguages"`
+}
+
+// filterConfig is the configuration file form of conv.OperationFilter
+type filterConfig struct {
+	Tags         []string          `yaml:"tags"`
+	Paths        []string          `yaml:"paths"`
+	Methods      []string          `yaml:"methods"`
+	OperationIDs []string          `yaml:"operationIds"`
+	Extensions   map[string]string `yaml:"extensions"`
+}
+
+// loadConfig reads the configuration file at path. When path is empty the default file is
+// loaded from the working directory if it exists; otherwise an empty config is returned.
+func loadConfig(path string) (*config, error) {
+	explicit := path != ""
+	if !explicit {
+		path = defaultConfigFile
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return &config{}, nil
+		}
+		return nil, err
+	}
+
+	var cfg config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	// Paths in the file are relative to the file itself
+	dir := filepath.Dir(path)
+	cfg.resolvePaths(dir)
+	for i := range cfg.Jobs {
+		cfg.Jobs[i].resolvePaths(dir)
+	}
+
+	return &cfg, nil
+}
+
+// jobs returns the fully merged jobs described by the file, with the top-level settings
+// applied to each job. A file without a jobs list describes a single job.
+func (c *config) jobs() []jobConfig {
+	if len(c.Jobs) == 0 {
+		return []jobConfig{c.jobConfig}
+	}
+
+	jobs := make([]jobConfig, 0, len(c.Jobs))
+	for _, job := range c.Jobs {
+		jobs = append(jobs, c.merge(job))
+	}
+	return jobs
+}
+
+// resolvePaths makes relative file paths relative to dir
+func (j *jobConfig) resolvePaths(dir string) {
+	for _, p := range []*string{&j.Input, &j.Output, &j.Inject} {
+		if *p != "" && *p != stdio && !filepath.IsAbs(*p) {
+			*p = filepath.Join(dir, *p)
+		}
+	}
+	for i, input := range j.Inputs {
+		if !filepath.IsAbs(input) {
+			j.Inputs[i] = filepath.Join(dir, input)
+		}
+	}
+}
+
+// merge returns j with every setting present in override applied on top
+func (j jobConfig) merge(override jobConfig) jobConfig {
+	// A single input and a list of inputs replace each other
+	if override.Input != "" {
+		j.Input, j.Inputs = override.Input, nil
+	}
+	if override.Inputs != nil {
+		j.Input, j.Inputs = "", override.Inputs
+	}
+	mergeString(&j.Output, override.Output)
+	mergeString(&j.Inject, override.Inject)
+	mergeString(&j.Title, override.Title)
+	mergeString(&j.Description, override.Description)
+	mergeString(&j.TOC, override.TOC)
+	mergeString(&j.Anchors, override.Anchors)
+	mergeString(&j.Deprecated, override.Deprecated)
+	mergeString(&j.AudienceExtension, override.AudienceExtension)
+
+	if override.SharedSchemas != nil {
+		j.SharedSchemas = override.SharedSchemas
+	}
+	if override.HeadingOffset != nil {
+		j.HeadingOffset = override.HeadingOffset
+	}
+	if override.MaxExamples != nil {
+		j.MaxExamples = override.MaxExamples
+	}
+	if override.ExcludeAudiences != nil {
+		j.ExcludeAudiences = override.ExcludeAudiences
+	}
+	if override.Strict != nil {
+		j.Strict = override.Strict
+	}
+	if override.WarningsAsErrors != nil {
+		j.WarningsAsErrors = override.WarningsAsErrors
+	}
+	if override.LintRules != nil {
+		j.LintRules = override.LintRules
+	}
+	if override.CodeSamples != nil {
+		j.CodeSamples = override.CodeSamples
+	}
+	if override.CodeSampleLanguages != nil {
+		j.CodeSampleLanguages = override.CodeSampleLanguages
+	}
+
+	j.Include = j.Include.merge(override.Include)
+	j.Exclude = j.Exclude.merge(override.Exclude)
+
+	return j
+}
+
+// merge returns f with every criterion present in override replacing its own
+func (f filterConfig) merge(override filterConfig) filterConfig {
+	if override.Tags != nil {
+		f.Tags = override.Tags
+	}
+	if override.Paths != nil {
+		f.Paths = override.Paths
+	}
+	if override.Methods != nil {
+		f.Methods = override.Methods
+	}
+	if override.OperationIDs != nil {
+		f.OperationIDs = override.OperationIDs
+	}
+	if override.Extensions != nil {
+		f.Extensions = override.Extensions
+	}
+	return f
+}
+
+// options converts the job settings into conv.ConvertOptions
+func (j jobConfig) options() conv.ConvertOptions {
+	opts := conv.ConvertOptions{
+		Title:             j.Title,
+		Description:       j.Description,
+		TOCStyle:          conv.TOCStyle(j.TOC),
+		Anchors:           conv.AnchorStrategy(j.Anchors),
+		Deprecated:        conv.DeprecatedMode(j.Deprecated),
+		ExcludeAudiences:  j.ExcludeAudiences,
+		AudienceExtension: j.AudienceExtension,
+		Include:           conv.OperationFilter(j.Include),
+		Exclude:           conv.OperationFilter(j.Exclude),
+	}
+
+	// Relative $ref file references resolve from the directory of the spec
+	if j.Input != "" && j.Input != stdio {
+		opts.BasePath = filepath.Dir(j.Input)
+	} else {
+		opts.BasePath = "."
+	}
+
+	if j.SharedSchemas != nil {
+		opts.EnableSharedSchemas = *j.SharedSchemas
+	}
+	if j.HeadingOffset != nil {
+		opts.HeadingOffset = *j.HeadingOffset
+	}
+	if j.MaxExamples != nil {
+		opts.MaxExamples = *j.MaxExamples
+	}
+	if j.Strict != nil {
+		opts.Strict = *j.Strict
+	}
+	if j.WarningsAsErrors != nil {
+		opts.WarningsAsErrors = *j.WarningsAsErrors
+	}
+	if j.CodeSamples != nil {
+		opts.DisableCodeSamples = !*j.CodeSamples
+	}
+	for _, lang := range j.CodeSampleLanguages {
+		opts.CodeSampleLanguages = append(opts.CodeSampleLanguages, conv.CodeSampleLanguage(lang))
+	}
+
+	return opts
+}
+
+func mergeString(dst *string, override string) {
+	if override != "" {
+		*dst = override
+	}
+}
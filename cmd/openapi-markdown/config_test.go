@@ -0,0 +1,234 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		contents *string
+		explicit bool
+		want     func(dir string) []jobConfig
+		wantErr  string
+	}{
+		{
+			name: "default file absent",
+			want: func(string) []jobConfig {
+				return []jobConfig{{}}
+			},
+		},
+		{
+			name:     "explicit file absent",
+			explicit: true,
+			wantErr:  "no such file or directory",
+		},
+		{
+			name:     "single job with paths relative to the file",
+			contents: ptr("input: specs/openapi.yaml\noutput: docs/api.md\ntitle: Pet Store\nheadingOffset: 1\n"),
+			want: func(dir string) []jobConfig {
+				return []jobConfig{{
+					Input:         filepath.Join(dir, "specs/openapi.yaml"),
+					Output:        filepath.Join(dir, "docs/api.md"),
+					Title:         "Pet Store",
+					HeadingOffset: ptr(1),
+				}}
+			},
+		},
+		{
+			name: "jobs inherit top-level settings",
+			contents: ptr(`title: Shared
+sharedSchemas: true
+output: "-"
+jobs:
+  - input: /abs/public.yaml
+    inject: README.md
+  - inputs: [a.yaml, b.yaml]
+    title: Internal
+    sharedSchemas: false
+`),
+			want: func(dir string) []jobConfig {
+				return []jobConfig{
+					{
+						Input:         "/abs/public.yaml",
+						Output:        stdio,
+						Inject:        filepath.Join(dir, "README.md"),
+						Title:         "Shared",
+						SharedSchemas: ptr(true),
+					},
+					{
+						Inputs:        []string{filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.yaml")},
+						Output:        stdio,
+						Title:         "Internal",
+						SharedSchemas: ptr(false),
+					},
+				}
+			},
+		},
+		{
+			name:     "unknown field",
+			contents: ptr("titel: Pet Store\n"),
+			wantErr:  "field titel not found",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Chdir(dir)
+
+			path := ""
+			if test.explicit || test.contents != nil {
+				path = filepath.Join(dir, "config.yaml")
+			}
+			if test.contents != nil {
+				require.NoError(t, os.WriteFile(path, []byte(*test.contents), 0o644))
+			}
+
+			cfg, err := loadConfig(path)
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want(dir), cfg.jobs())
+		})
+	}
+}
+
+func TestJobConfigMerge(t *testing.T) {
+	base := jobConfig{
+		Input:            "openapi.yaml",
+		Output:           "api.md",
+		Title:            "Base",
+		HeadingOffset:    ptr(1),
+		ExcludeAudiences: []string{"internal"},
+		Include:          filterConfig{Tags: []string{"pets"}, Methods: []string{"get"}},
+	}
+
+	for _, test := range []struct {
+		name     string
+		override jobConfig
+		want     jobConfig
+	}{
+		{
+			name: "empty override keeps everything",
+			want: base,
+		},
+		{
+			name: "set fields replace, zero pointers override",
+			override: jobConfig{
+				Title:         "Override",
+				HeadingOffset: ptr(0),
+				Strict:        ptr(true),
+			},
+			want: jobConfig{
+				Input:            "openapi.yaml",
+				Output:           "api.md",
+				Title:            "Override",
+				HeadingOffset:    ptr(0),
+				Strict:           ptr(true),
+				ExcludeAudiences: []string{"internal"},
+				Include:          filterConfig{Tags: []string{"pets"}, Methods: []string{"get"}},
+			},
+		},
+		{
+			name:     "inputs replace input",
+			override: jobConfig{Inputs: []string{"a.yaml", "b.yaml"}},
+			want: jobConfig{
+				Inputs:           []string{"a.yaml", "b.yaml"},
+				Output:           "api.md",
+				Title:            "Base",
+				HeadingOffset:    ptr(1),
+				ExcludeAudiences: []string{"internal"},
+				Include:          filterConfig{Tags: []string{"pets"}, Methods: []string{"get"}},
+			},
+		},
+		{
+			name: "filter criteria merge individually",
+			override: jobConfig{
+				ExcludeAudiences: []string{},
+				Include:          filterConfig{Tags: []string{"users"}},
+			},
+			want: jobConfig{
+				Input:            "openapi.yaml",
+				Output:           "api.md",
+				Title:            "Base",
+				HeadingOffset:    ptr(1),
+				ExcludeAudiences: []string{},
+				Include:          filterConfig{Tags: []string{"users"}, Methods: []string{"get"}},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, base.merge(test.override))
+		})
+	}
+}
+
+func TestConvertFlagsJobs(t *testing.T) {
+	const contents = `title: From Config
+headingOffset: 1
+codeSamples: false
+jobs:
+  - input: public.yaml
+  - input: internal.yaml
+    title: Internal
+`
+
+	for _, test := range []struct {
+		name string
+		args []string
+		want func(dir string) []jobConfig
+	}{
+		{
+			name: "config file only",
+			want: func(dir string) []jobConfig {
+				return []jobConfig{
+					{Input: filepath.Join(dir, "public.yaml"), Title: "From Config", HeadingOffset: ptr(1), CodeSamples: ptr(false)},
+					{Input: filepath.Join(dir, "internal.yaml"), Title: "Internal", HeadingOffset: ptr(1), CodeSamples: ptr(false)},
+				}
+			},
+		},
+		{
+			name: "flags override every job",
+			args: []string{"-title", "From Flag", "-heading-offset", "0", "-code-samples"},
+			want: func(dir string) []jobConfig {
+				return []jobConfig{
+					{Input: filepath.Join(dir, "public.yaml"), Title: "From Flag", HeadingOffset: ptr(0), CodeSamples: ptr(true)},
+					{Input: filepath.Join(dir, "internal.yaml"), Title: "From Flag", HeadingOffset: ptr(0), CodeSamples: ptr(true)},
+				}
+			},
+		},
+		{
+			name: "arguments replace the jobs",
+			args: []string{"-strict", "other.yaml"},
+			want: func(string) []jobConfig {
+				return []jobConfig{
+					{Input: "other.yaml", Title: "From Config", HeadingOffset: ptr(1), CodeSamples: ptr(false), Strict: ptr(true)},
+				}
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "openapi-markdown.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+
+			var flags convertFlags
+			fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			flags.register(fs)
+			require.NoError(t, fs.Parse(append([]string{"-config", path}, test.args...)))
+
+			jobs, err := flags.jobs(fs)
+			require.NoError(t, err)
+			assert.Equal(t, test.want(dir), jobs)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// runConvert implements the convert command, the default when no command is given
+func runConvert(args []string) int {
+	fs := newFlagSet("convert", "[flags] [openapi-file...]", `Converts OpenAPI specs to markdown documentation.
+Use - as the file to read the spec from stdin. Several files or a glob pattern
+convert each spec into the -o directory along with an index page. Without a file,
+the jobs in the configuration file are run.`)
+	var flags convertFlags
+	flags.register(fs)
+	fs.StringVar(&flags.job.Output, "o", "", "output file path, or - for stdout (defaults to input filename with .md extension, or stdout when reading stdin); the output directory when converting several specs")
+	fs.StringVar(&flags.job.Inject, "inject", "", "replace the content between openapi-markdown:start/end markers in this file instead of writing -o")
+	watch := fs.Bool("watch", false, "regenerate the output whenever the spec or a file it references changes")
+	check := fs.Bool("check", false, "compare the generated output with the existing file instead of writing it; exit 1 if they differ")
+	if err := fs.Parse(args); err != nil {
+		return parseExitCode(err)
+	}
+
+	jobs, err := flags.jobs(fs)
+	if err != nil {
+		return reportError(fmt.Errorf("loading config: %w", err))
+	}
+
+	for _, job := range jobs {
+		if job.Input == "" && len(job.Inputs) == 0 {
+			fs.Usage()
+			return exitError
+		}
+	}
+
+	if len(jobs) > 1 && (flags.job.Output != "" || flags.job.Inject != "") {
+		return reportError(fmt.Errorf("-o and -inject cannot be used with multiple jobs"))
+	}
+
+	if *watch {
+		if *check {
+			return reportError(fmt.Errorf("-watch cannot be used with -check"))
+		}
+		return reportError(watchJobs(jobs))
+	}
+
+	code := exitOK
+	for _, job := range jobs {
+		code = max(code, reportError(runJob(job, *check)))
+	}
+	return code
+}
+
+// runJob converts a single spec and writes, injects or checks its output
+func runJob(job jobConfig, check bool) error {
+	if job.isBatch() {
+		return runBatch(job, check)
+	}
+
+	baseName := specBaseName(job.Input)
+	if job.Input == stdio {
+		baseName = "API"
+	}
+
+	if job.Title == "" {
+		job.Title = baseName
+	}
+
+	if job.Inject != "" {
+		if job.Output != "" {
+			return fmt.Errorf("output and inject cannot be used together")
+		}
+		job.Output = job.Inject
+	}
+
+	if job.Output == "" {
+		if job.Input == stdio {
+			job.Output = stdio
+		} else {
+			job.Output = baseName + ".md"
+		}
+	}
+
+	if check && job.Output == stdio {
+		return fmt.Errorf("-check requires an output file")
+	}
+
+	result, err := convertSpec(job.Input, job.options())
+	if err != nil {
+		return err
+	}
+	printWarnings(os.Stderr, job.Input, result)
+
+	content := result.Markdown
+	if job.Inject != "" {
+		doc, err := os.ReadFile(job.Inject)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", job.Inject, err)
+		}
+
+		content, err = injectMarkdown(doc, content)
+		if err != nil {
+			return fmt.Errorf("injecting into %s: %w", job.Inject, err)
+		}
+	}
+
+	return writeOutput(job.Output, job.Input, content, check)
+}
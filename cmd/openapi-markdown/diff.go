@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// runDiff implements the diff command, which converts two versions of a spec with the same
+// settings and prints a unified diff of the generated documentation
+func runDiff(args []string) int {
+	fs := newFlagSet("diff", "[flags] old-openapi-file new-openapi-file", `Converts both specs with the same settings and prints a unified diff of the
+generated markdown. Exits with 1 when the documentation differs.`)
+	var flags convertFlags
+	flags.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return parseExitCode(err)
+	}
+
+	jobs, err := flags.jobs(fs)
+	if err != nil {
+		return reportError(fmt.Errorf("loading config: %w", err))
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitError
+	}
+
+	job := jobs[0]
+	if job.Title == "" {
+		// Both documents share a title so the diff only shows real changes
+		job.Title = specBaseName(fs.Arg(1))
+	}
+
+	var docs [2][]byte
+	for i, input := range fs.Args() {
+		spec := job
+		spec.Inputs = nil
+		spec.Input = input
+
+		result, err := convertSpec(spec.Input, spec.options())
+		if err != nil {
+			return reportError(err)
+		}
+		docs[i] = result.Markdown
+	}
+
+	if bytes.Equal(docs[0], docs[1]) {
+		return exitOK
+	}
+
+	diff, err := unifiedDiff(docs[0], docs[1], fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		return reportError(err)
+	}
+	fmt.Print(diff)
+	return exitFindings
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"flag"
+	"strings"
+)
+
+// convertFlags are the conversion settings shared by every command that converts a spec
+type convertFlags struct {
+	config           string
+	job              jobConfig
+	sharedSchemas    bool
+	headingOffset    int
+	maxExamples      int
+	warningsAsErrors bool
+	strict           bool
+	codeSamples      bool
+	excludeAudiences stringList
+	codeSampleLangs  stringList
+	include          filterFlags
+	exclude          filterFlags
+}
+
+// register adds the conversion flags to fs
+func (c *convertFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.config, "config", "", "configuration file (defaults to "+defaultConfigFile+" in the working directory when present)")
+	fs.StringVar(&c.job.Title, "title", "", "API documentation title (defaults to input filename); the index page title when converting several specs")
+	fs.StringVar(&c.job.Description, "description", "", "API documentation description")
+	fs.BoolVar(&c.sharedSchemas, "shared-schemas", false, "enable shared schema definitions")
+	fs.StringVar(&c.job.TOC, "toc", "", "table of contents style: table (default) or list")
+	fs.IntVar(&c.headingOffset, "heading-offset", 0, "shift every generated heading down by this many levels")
+	fs.IntVar(&c.maxExamples, "max-examples", 0, "render at most this many named examples per request body and response (0 renders all)")
+	fs.StringVar(&c.job.Anchors, "anchors", "", "operation anchor strategy: compact (default), operation-id or github")
+	fs.StringVar(&c.job.Deprecated, "deprecated", "", "deprecated operation handling: inline (default), hide or section")
+	fs.StringVar(&c.job.AudienceExtension, "audience-extension", "", "vendor extension holding audience values (default x-audience)")
+	fs.Var(&c.excludeAudiences, "exclude-audience", "remove operations, parameters, responses and fields for this audience (repeatable)")
+	fs.BoolVar(&c.strict, "strict", false, "fail on constructs the documentation would leave out, with their spec location")
+	fs.BoolVar(&c.warningsAsErrors, "warnings-as-errors", false, "fail the conversion when it raises warnings")
+	fs.BoolVar(&c.codeSamples, "code-samples", true, "render example requests for each operation")
+	fs.Var(&c.codeSampleLangs, "code-sample-language", "example request language: curl (default), go, python, javascript or httpie (repeatable)")
+	c.include.register(fs, "include")
+	c.exclude.register(fs, "exclude")
+}
+
+// jobs combines the configuration file with the flags set on the command line. Input files
+// given as arguments form a single job with the top-level settings; otherwise every job of the
+// configuration file is returned.
+func (c *convertFlags) jobs(fs *flag.FlagSet) ([]jobConfig, error) {
+	override := c.job
+
+	// Only flags given on the command line override the configuration file
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "shared-schemas":
+			override.SharedSchemas = &c.sharedSchemas
+		case "heading-offset":
+			override.HeadingOffset = &c.headingOffset
+		case "max-examples":
+			override.MaxExamples = &c.maxExamples
+		case "strict":
+			override.Strict = &c.strict
+		case "warnings-as-errors":
+			override.WarningsAsErrors = &c.warningsAsErrors
+		case "code-samples":
+			override.CodeSamples = &c.codeSamples
+		}
+	})
+	override.ExcludeAudiences = c.excludeAudiences
+	override.CodeSampleLanguages = c.codeSampleLangs
+	override.Include = c.include.filter()
+	override.Exclude = c.exclude.filter()
+
+	cfg, err := loadConfig(c.config)
+	if err != nil {
+		return nil, err
+	}
+
+	if fs.NArg() > 0 {
+		if fs.NArg() == 1 {
+			override.Input = fs.Arg(0)
+		} else {
+			override.Inputs = fs.Args()
+		}
+		return []jobConfig{cfg.merge(override)}, nil
+	}
+
+	var jobs []jobConfig
+	for _, job := range cfg.jobs() {
+		jobs = append(jobs, job.merge(override))
+	}
+	return jobs, nil
+}
+
+// stringList is a flag.Value that collects every occurrence of a repeatable flag
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// filterFlags holds the repeatable flags that make up an operation filter
+type filterFlags struct {
+	tags         stringList
+	paths        stringList
+	methods      stringList
+	operationIDs stringList
+	extensions   stringList
+}
+
+// register adds the -<prefix>-tag, -<prefix>-path, -<prefix>-method, -<prefix>-operation
+// and -<prefix>-extension flags
+func (f *filterFlags) register(fs *flag.FlagSet, prefix string) {
+	fs.Var(&f.tags, prefix+"-tag", prefix+" operations with this tag (repeatable)")
+	fs.Var(&f.paths, prefix+"-path", prefix+" operations whose path matches this glob, e.g. /admin/** (repeatable)")
+	fs.Var(&f.methods, prefix+"-method", prefix+" operations with this HTTP method (repeatable)")
+	fs.Var(&f.operationIDs, prefix+"-operation", prefix+" operations with this operationId (repeatable)")
+	fs.Var(&f.extensions, prefix+"-extension", prefix+" operations with this vendor extension, as key or key=value (repeatable)")
+}
+
+// filter converts the collected flags into a filterConfig, leaving unset criteria nil
+func (f *filterFlags) filter() filterConfig {
+	filter := filterConfig{
+		Tags:         f.tags,
+		Paths:        f.paths,
+		Methods:      f.methods,
+		OperationIDs: f.operationIDs,
+	}
+
+	for _, ext := range f.extensions {
+		if filter.Extensions == nil {
+			filter.Extensions = make(map[string]string)
+		}
+		key, value, _ := strings.Cut(ext, "=")
+		filter.Extensions[key] = value
+	}
+
+	return filter
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	headingPattern   = regexp.MustCompile(`^(#{1,6}) (.*)$`)
+	listItemPattern  = regexp.MustCompile(`^( *)[-*] (.*)$`)
+	tableRulePattern = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+	anchorPattern    = regexp.MustCompile(`^<a id="([^"<>]*)"></a>$`)
+	linkPattern      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	boldPattern      = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	italicPattern    = regexp.MustCompile(`\*(.+?)\*`)
+)
+
+// markdownToHTML renders the subset of markdown produced by the converter: headings, paragraphs,
+// tables, fenced code blocks, nested bullet lists, blockquotes, operation anchors and inline code,
+// emphasis and links. Headings get GitHub-style ids so generated links resolve. Spec text reaches
+// the markdown, so any other HTML is escaped and only web and in-page links are kept.
+func markdownToHTML(markdown []byte) string {
+	r := htmlRenderer{ids: make(map[string]int)}
+	r.render(strings.Split(string(markdown), "\n"))
+	return r.out.String()
+}
+
+type htmlRenderer struct {
+	out strings.Builder
+	ids map[string]int
+}
+
+func (r *htmlRenderer) render(lines []string) {
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			fmt.Fprintf(&r.out, "<p>%s</p>\n", renderInline(strings.Join(paragraph, "\n")))
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+
+		case strings.HasPrefix(line, "```"):
+			flush()
+			lang := strings.TrimPrefix(line, "```")
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(lines[i], "```"); i++ {
+				code = append(code, lines[i])
+			}
+			class := ""
+			if lang != "" {
+				class = fmt.Sprintf(` class="language-%s"`, html.EscapeString(lang))
+			}
+			fmt.Fprintf(&r.out, "<pre><code%s>%s</code></pre>\n", class, html.EscapeString(strings.Join(code, "\n")))
+
+		case headingPattern.MatchString(line):
+			flush()
+			m := headingPattern.FindStringSubmatch(line)
+			level := len(m[1])
+			fmt.Fprintf(&r.out, "<h%d id=\"%s\">%s</h%d>\n", level, r.headingID(m[2]), renderInline(m[2]), level)
+
+		case anchorPattern.MatchString(line):
+			flush()
+			m := anchorPattern.FindStringSubmatch(line)
+			fmt.Fprintf(&r.out, "<a id=\"%s\"></a>\n", html.EscapeString(html.UnescapeString(m[1])))
+
+		case strings.HasPrefix(line, ">"):
+			flush()
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(lines[i], ">"); i++ {
+				quote = append(quote, strings.TrimPrefix(strings.TrimPrefix(lines[i], ">"), " "))
+			}
+			i--
+			r.out.WriteString("<blockquote>\n")
+			r.render(quote)
+			r.out.WriteString("</blockquote>\n")
+
+		case listItemPattern.MatchString(line):
+			flush()
+			var items []string
+			for ; i < len(lines) && listItemPattern.MatchString(lines[i]); i++ {
+				items = append(items, lines[i])
+			}
+			i--
+			r.renderList(items)
+
+		case strings.Contains(line, "|") && i+1 < len(lines) && tableRulePattern.MatchString(strings.TrimSpace(lines[i+1])):
+			flush()
+			var rows [][]string
+			rows = append(rows, splitTableRow(line))
+			for i += 2; i < len(lines) && strings.Contains(lines[i], "|"); i++ {
+				rows = append(rows, splitTableRow(lines[i]))
+			}
+			i--
+			r.renderTable(rows)
+
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+
+	flush()
+}
+
+// renderList renders bullet list lines, nesting items by their indentation
+func (r *htmlRenderer) renderList(items []string) {
+	var indents []int
+
+	for _, item := range items {
+		m := listItemPattern.FindStringSubmatch(item)
+		indent := len(m[1])
+
+		switch {
+		case len(indents) == 0 || indent > indents[len(indents)-1]:
+			r.out.WriteString("<ul>\n")
+			indents = append(indents, indent)
+		default:
+			for len(indents) > 1 && indent < indents[len(indents)-1] {
+				r.out.WriteString("</li>\n</ul>\n")
+				indents = indents[:len(indents)-1]
+			}
+			r.out.WriteString("</li>\n")
+		}
+
+		fmt.Fprintf(&r.out, "<li>%s", renderInline(m[2]))
+	}
+
+	for range indents {
+		r.out.WriteString("</li>\n</ul>\n")
+	}
+}
+
+// renderTable renders a header row followed by body rows
+func (r *htmlRenderer) renderTable(rows [][]string) {
+	r.out.WriteString("<table>\n<thead>\n<tr>")
+	for _, cell := range rows[0] {
+		fmt.Fprintf(&r.out, "<th>%s</th>", renderInline(cell))
+	}
+	r.out.WriteString("</tr>\n</thead>\n<tbody>\n")
+
+	for _, row := range rows[1:] {
+		r.out.WriteString("<tr>")
+		for _, cell := range row {
+			fmt.Fprintf(&r.out, "<td>%s</td>", renderInline(cell))
+		}
+		r.out.WriteString("</tr>\n")
+	}
+	r.out.WriteString("</tbody>\n</table>\n")
+}
+
+// headingID returns the GitHub-style id for a heading, adding a numeric suffix to repeats
+func (r *htmlRenderer) headingID(text string) string {
+	var id strings.Builder
+	for _, c := range strings.ToLower(strings.ReplaceAll(text, "`", "")) {
+		switch {
+		case unicode.IsLetter(c) || unicode.IsNumber(c) || unicode.In(c, unicode.M, unicode.Pc) || c == '-':
+			id.WriteRune(c)
+		case c == ' ':
+			id.WriteRune('-')
+		}
+	}
+
+	slug := id.String()
+	count := r.ids[slug]
+	r.ids[slug]++
+	if count > 0 {
+		slug = fmt.Sprintf("%s-%d", slug, count)
+	}
+	return slug
+}
+
+// splitTableRow splits a table row on unescaped pipes, ignoring leading and trailing pipes
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if !strings.HasSuffix(line, `\|`) {
+		line = strings.TrimSuffix(line, "|")
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// renderInline renders inline code, links, bold and italic text, escaping everything else
+func renderInline(text string) string {
+	var out strings.Builder
+
+	// Odd segments between backticks are code spans and are not formatted further
+	for i, segment := range strings.Split(text, "`") {
+		if i%2 == 1 {
+			fmt.Fprintf(&out, "<code>%s</code>", html.EscapeString(segment))
+			continue
+		}
+
+		segment = html.EscapeString(segment)
+		segment = linkPattern.ReplaceAllStringFunc(segment, renderLink)
+		segment = boldPattern.ReplaceAllString(segment, "<strong>$1</strong>")
+		segment = italicPattern.ReplaceAllString(segment, "<em>$1</em>")
+		out.WriteString(segment)
+	}
+
+	return out.String()
+}
+
+// renderLink renders an escaped markdown link, keeping only the text of links whose target is
+// not an http(s), relative or in-page URL, such as javascript: links
+func renderLink(link string) string {
+	m := linkPattern.FindStringSubmatch(link)
+	target, err := url.Parse(html.UnescapeString(m[2]))
+	if err != nil || (target.Scheme != "" && target.Scheme != "http" && target.Scheme != "https") {
+		return m[1]
+	}
+	return fmt.Sprintf(`<a href="%s">%s</a>`, m[2], m[1])
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{
+			name:     "heading and paragraph",
+			markdown: "# Pet API\n\nManages pets\nand owners\n",
+			want:     "<h1 id=\"pet-api\">Pet API</h1>\n<p>Manages pets\nand owners</p>\n",
+		},
+		{
+			name:     "repeated headings",
+			markdown: "## Responses\n\n## Responses\n",
+			want:     "<h2 id=\"responses\">Responses</h2>\n<h2 id=\"responses-1\">Responses</h2>\n",
+		},
+		{
+			name:     "fenced code block",
+			markdown: "```json\n{\"a\": \"<b>\"}\n```\n",
+			want:     "<pre><code class=\"language-json\">{&#34;a&#34;: &#34;&lt;b&gt;&#34;}</code></pre>\n",
+		},
+		{
+			name:     "nested list",
+			markdown: "- `owner` *(object)*\n  - `name` *(string)*\n- `id` *(string)*\n",
+			want: "<ul>\n<li><code>owner</code> <em>(object)</em><ul>\n<li><code>name</code> <em>(string)</em></li>\n</ul>\n" +
+				"</li>\n<li><code>id</code> <em>(string)</em></li>\n</ul>\n",
+		},
+		{
+			name:     "table with escaped pipe",
+			markdown: "HTTP Request | Description\n-------------|------------\nGET [/pets](#getpets) | cats \\| dogs\n",
+			want: "<table>\n<thead>\n<tr><th>HTTP Request</th><th>Description</th></tr>\n</thead>\n<tbody>\n" +
+				"<tr><td>GET <a href=\"#getpets\">/pets</a></td><td>cats | dogs</td></tr>\n</tbody>\n</table>\n",
+		},
+		{
+			name:     "blockquote",
+			markdown: "> **Deprecated:** Use v2.\n>\n> **Sunset:** 2025-01-01\n",
+			want: "<blockquote>\n<p><strong>Deprecated:</strong> Use v2.</p>\n" +
+				"<p><strong>Sunset:</strong> 2025-01-01</p>\n</blockquote>\n",
+		},
+		{
+			name:     "raw html anchor",
+			markdown: "<a id=\"getpets\"></a>\n",
+			want:     "<a id=\"getpets\"></a>\n",
+		},
+		{
+			name:     "script line is escaped",
+			markdown: "<script>alert(1)</script>\n",
+			want:     "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n",
+		},
+		{
+			name:     "anchor with extra attributes is escaped",
+			markdown: "<a id=\"x\" onclick=\"alert(1)\"></a>\n",
+			want:     "<p>&lt;a id=&#34;x&#34; onclick=&#34;alert(1)&#34;&gt;&lt;/a&gt;</p>\n",
+		},
+		{
+			name:     "inline html is escaped",
+			markdown: "Pets <img src=x onerror=alert(1)>\n",
+			want:     "<p>Pets &lt;img src=x onerror=alert(1)&gt;</p>\n",
+		},
+		{
+			name:     "web and in-page links",
+			markdown: "[docs](https://example.com/a?b=1&c=2) [pets](pets.md) [top](#pet-api) [http](http://example.com)\n",
+			want: "<p><a href=\"https://example.com/a?b=1&amp;c=2\">docs</a> <a href=\"pets.md\">pets</a> " +
+				"<a href=\"#pet-api\">top</a> <a href=\"http://example.com\">http</a></p>\n",
+		},
+		{
+			name:     "script links keep only their text",
+			markdown: "[click](javascript:alert(1)) [CLICK](JavaScript:alert(1)) [data](data:text/html;base64,PHNjcmlwdD4=)\n",
+			want:     "<p>click) CLICK) data</p>\n",
+		},
+		{
+			name:     "inline code is not formatted",
+			markdown: "Send `**raw** <x>` as **bold** text\n",
+			want:     "<p>Send <code>**raw** &lt;x&gt;</code> as <strong>bold</strong> text</p>\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, markdownToHTML([]byte(test.markdown)))
+		})
+	}
+}
+
+func TestHeadingID(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "words", text: "Shared Schema Definitions", want: "shared-schema-definitions"},
+		{name: "operation", text: "GET /pets/{id}", want: "get-petsid"},
+		{name: "code span", text: "`Pet` schema", want: "pet-schema"},
+		{name: "hyphen and underscore", text: "x-tag_groups", want: "x-tag_groups"},
+		{name: "punctuation", text: "Deprecated (v1)!", want: "deprecated-v1"},
+		{name: "unicode", text: "Über Café", want: "über-café"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			r := htmlRenderer{ids: make(map[string]int)}
+			assert.Equal(t, test.want, r.headingID(test.text))
+		})
+	}
+}
+
+func TestSplitTableRow(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		line string
+		want []string
+	}{
+		{name: "without outer pipes", line: "GET | List pets", want: []string{"GET", "List pets"}},
+		{name: "with outer pipes", line: "| GET | List pets |", want: []string{"GET", "List pets"}},
+		{name: "escaped pipe", line: `a \| b | c`, want: []string{"a | b", "c"}},
+		{name: "escaped trailing pipe", line: `| a | b \|`, want: []string{"a", "b |"}},
+		{name: "empty cell", line: "| a |  | c |", want: []string{"a", "", "c"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, splitTableRow(test.line))
+		})
+	}
+}
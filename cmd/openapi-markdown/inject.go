@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const (
+	injectStartMarker = "<!-- openapi-markdown:start -->"
+	injectEndMarker   = "<!-- openapi-markdown:end -->"
+)
+
+// injectMarkdown replaces the content between the start and end markers in doc with the
+// generated markdown, leaving everything outside the markers untouched
+func injectMarkdown(doc, generated []byte) ([]byte, error) {
+	start := bytes.Index(doc, []byte(injectStartMarker))
+	if start == -1 {
+		return nil, fmt.Errorf("start marker %s not found", injectStartMarker)
+	}
+	contentStart := start + len(injectStartMarker)
+
+	end := bytes.Index(doc[contentStart:], []byte(injectEndMarker))
+	if end == -1 {
+		return nil, fmt.Errorf("end marker %s not found after start marker", injectEndMarker)
+	}
+	end += contentStart
+
+	var out bytes.Buffer
+	out.Write(doc[:contentStart])
+	out.WriteString("\n\n")
+	out.Write(bytes.TrimRight(generated, "\n"))
+	out.WriteString("\n\n")
+	out.Write(doc[end:])
+
+	return out.Bytes(), nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjectMarkdown(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		doc     string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "replaces content between markers",
+			doc: "# Project\n\n<!-- openapi-markdown:start -->\nstale docs\n<!-- openapi-markdown:end -->\n\n" +
+				"## License\n",
+			want: "# Project\n\n<!-- openapi-markdown:start -->\n\n# API\n\n<!-- openapi-markdown:end -->\n\n" +
+				"## License\n",
+		},
+		{
+			name: "adjacent markers",
+			doc:  "<!-- openapi-markdown:start --><!-- openapi-markdown:end -->",
+			want: "<!-- openapi-markdown:start -->\n\n# API\n\n<!-- openapi-markdown:end -->",
+		},
+		{
+			name:    "missing start marker",
+			doc:     "# Project\n<!-- openapi-markdown:end -->\n",
+			wantErr: "start marker <!-- openapi-markdown:start --> not found",
+		},
+		{
+			name:    "missing end marker",
+			doc:     "# Project\n<!-- openapi-markdown:start -->\n",
+			wantErr: "end marker <!-- openapi-markdown:end --> not found after start marker",
+		},
+		{
+			name:    "markers out of order",
+			doc:     "<!-- openapi-markdown:end -->\n<!-- openapi-markdown:start -->\n",
+			wantErr: "end marker <!-- openapi-markdown:end --> not found after start marker",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := injectMarkdown([]byte(test.doc), []byte("# API\n\n"))
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+)
+
+// lintFormats are the output formats of the lint command
+var lintFormats = []string{"text", "json", "sarif"}
+
+// lintedFinding is a finding together with the spec it was found in
+type lintedFinding struct {
+	file    string
+	finding conv.LintFinding
+}
+
+// runLint implements the lint command, which checks each spec for documentation gaps
+func runLint(args []string) int {
+	fs := newFlagSet("lint", "[flags] [openapi-file...]", `Checks each spec for documentation gaps such as missing descriptions, examples
+and error responses. Without a file, the specs of the configuration file jobs are
+linted. Exits with 1 when any finding has warning or error severity.`)
+	var flags convertFlags
+	flags.register(fs)
+	format := fs.String("format", "text", "output format: "+strings.Join(lintFormats, ", "))
+	var rules stringList
+	fs.Var(&rules, "rule", "set the severity of a rule as rule=error|warning|info|off (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return parseExitCode(err)
+	}
+
+	if !slices.Contains(lintFormats, *format) {
+		return reportError(fmt.Errorf("unsupported format: %s", *format))
+	}
+
+	specs, err := specJobs(fs, &flags)
+	if err != nil {
+		return reportError(err)
+	}
+	if len(specs) == 0 {
+		fs.Usage()
+		return exitError
+	}
+
+	code := exitOK
+	var found []lintedFinding
+	for _, spec := range specs {
+		opts := conv.LintOptions{ConvertOptions: spec.options(), Rules: spec.lintRules()}
+		for _, rule := range rules {
+			name, severity, _ := strings.Cut(rule, "=")
+			if opts.Rules == nil {
+				opts.Rules = make(map[conv.LintRule]conv.Severity)
+			}
+			opts.Rules[conv.LintRule(name)] = conv.Severity(severity)
+		}
+
+		openapi, err := readInput(spec.Input)
+		if err != nil {
+			code = max(code, reportError(fmt.Errorf("reading %s: %w", spec.Input, err)))
+			continue
+		}
+		result, err := conv.Lint(openapi, opts)
+		if err != nil {
+			code = max(code, reportError(fmt.Errorf("linting %s: %w", spec.Input, err)))
+			continue
+		}
+
+		for _, finding := range result.Findings {
+			found = append(found, lintedFinding{file: spec.Input, finding: finding})
+		}
+	}
+
+	switch *format {
+	case "json":
+		err = writeJSON(lintJSON(found))
+	case "sarif":
+		err = writeJSON(lintSARIF(found))
+	default:
+		for _, f := range found {
+			fmt.Printf("%s: %s: %s [%s]\n", f.file, f.finding.Severity, f.finding, f.finding.Rule)
+		}
+	}
+	if err != nil {
+		return reportError(err)
+	}
+
+	failing := 0
+	for _, f := range found {
+		if f.finding.Severity == conv.SeverityError || f.finding.Severity == conv.SeverityWarning {
+			failing++
+		}
+	}
+	if failing > 0 {
+		code = max(code, reportError(findings("%d findings", failing)))
+	}
+	return code
+}
+
+// lintRules converts the lintRules configuration setting into conv.LintOptions.Rules
+func (j jobConfig) lintRules() map[conv.LintRule]conv.Severity {
+	if len(j.LintRules) == 0 {
+		return nil
+	}
+	rules := make(map[conv.LintRule]conv.Severity, len(j.LintRules))
+	for rule, severity := range j.LintRules {
+		rules[conv.LintRule(rule)] = conv.Severity(severity)
+	}
+	return rules
+}
+
+// jsonFinding is the JSON output format of a finding
+type jsonFinding struct {
+	File      string `json:"file"`
+	Rule      string `json:"rule"`
+	Severity  string `json:"severity"`
+	Message   string `json:"message"`
+	Pointer   string `json:"pointer"`
+	Operation string `json:"operation,omitempty"`
+}
+
+func lintJSON(found []lintedFinding) []jsonFinding {
+	out := make([]jsonFinding, 0, len(found))
+	for _, f := range found {
+		out = append(out, jsonFinding{
+			File:      f.file,
+			Rule:      string(f.finding.Rule),
+			Severity:  string(f.finding.Severity),
+			Message:   f.finding.Message,
+			Pointer:   f.finding.Pointer,
+			Operation: f.finding.Operation,
+		})
+	}
+	return out
+}
+
+// sarifLog is the subset of the SARIF 2.1.0 format understood by code scanning tools
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+func lintSARIF(found []lintedFinding) sarifLog {
+	driver := sarifDriver{
+		Name:           "openapi-markdown",
+		InformationURI: "https://github.com/duh-rpc/openapi-markdown.go",
+	}
+	for _, rule := range conv.LintRules() {
+		driver.Rules = append(driver.Rules, sarifRule{ID: string(rule), ShortDescription: sarifMessage{Text: rule.Description()}})
+	}
+
+	results := make([]sarifResult, 0, len(found))
+	for _, f := range found {
+		location := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(f.file)}},
+		}
+		if f.finding.Pointer != "" {
+			location.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: f.finding.Pointer}}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    string(f.finding.Rule),
+			Level:     sarifLevel(f.finding.Severity),
+			Message:   sarifMessage{Text: f.finding.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+}
+
+// sarifLevel maps a severity to a SARIF result level
+func sarifLevel(severity conv.Severity) string {
+	if severity == conv.SeverityInfo {
+		return "note"
+	}
+	return string(severity)
+}
+
+// writeJSON writes v to stdout as indented JSON
+func writeJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -1,61 +1,234 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
-	"path/filepath"
-	"strings"
 
 	conv "github.com/duh-rpc/openapi-markdown.go"
 )
 
+// Exit codes shared by every command
+const (
+	// exitOK means the command succeeded and found nothing to report
+	exitOK = 0
+	// exitFindings means the command ran but found stale docs, lint findings or differences
+	exitFindings = 1
+	// exitError means the command could not run: bad usage, configuration or spec
+	exitError = 2
+)
+
+// command is a subcommand of the CLI
+type command struct {
+	name        string
+	description string
+	run         func(args []string) int
+}
+
+var commands = []command{
+	{name: "convert", description: "convert specs to markdown (default)", run: runConvert},
+	{name: "lint", description: "report documentation problems in specs", run: runLint},
+	{name: "diff", description: "show how the generated docs differ between two specs", run: runDiff},
+	{name: "stats", description: "summarize the operations, parameters and schemas of specs", run: runStats},
+	{name: "serve", description: "preview the docs as HTML with auto-reload", run: runServe},
+}
+
 func main() {
-	title := flag.String("title", "", "API documentation title (defaults to input filename)")
-	description := flag.String("description", "", "API documentation description")
-	output := flag.String("o", "", "output file path (defaults to input filename with .md extension)")
-	sharedSchemas := flag.Bool("shared-schemas", false, "enable shared schema definitions")
-	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: openapi-markdown [flags] <openapi-file>\n\nConverts an OpenAPI 3.x YAML file to markdown documentation.\n\nFlags:\n")
-		flag.PrintDefaults()
+	os.Exit(run(os.Args[1:]))
+}
+
+// run dispatches the command line arguments to their command and returns its exit code
+func run(args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "help", "-h", "-help", "--help":
+			usage()
+			return exitOK
+		}
+
+		for _, cmd := range commands {
+			if cmd.name == args[0] {
+				return cmd.run(args[1:])
+			}
+		}
+	}
+
+	// Without a command the arguments are passed to convert
+	return runConvert(args)
+}
+
+// usage prints the list of commands
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: openapi-markdown <command> [flags] [openapi-file...]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", cmd.name, cmd.description)
 	}
-	flag.Parse()
+	fmt.Fprintf(os.Stderr, "\nRun openapi-markdown <command> -h for the flags of a command.\n")
+	fmt.Fprintf(os.Stderr, "Exit codes: %d success, %d stale docs, findings or differences, %d errors.\n", exitOK, exitFindings, exitError)
+}
 
-	if flag.NArg() < 1 {
-		flag.Usage()
-		os.Exit(1)
+// newFlagSet creates the flag set of a command with a usage message built from its arguments
+// and description
+func newFlagSet(name, arguments, description string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: openapi-markdown %s %s\n\n%s\n\nFlags:\n", name, arguments, description)
+		fs.PrintDefaults()
 	}
+	return fs
+}
 
-	inputFile := flag.Arg(0)
-	baseName := strings.TrimSuffix(filepath.Base(inputFile), filepath.Ext(inputFile))
+// parseExitCode returns the exit code for a flag parsing error; asking for help is not an error
+func parseExitCode(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	return exitError
+}
 
-	if *title == "" {
-		*title = baseName
+// findingsError reports a result the command was asked to detect, such as stale docs, lint
+// findings or differences between specs, as opposed to a failure to run
+type findingsError struct {
+	message string
+}
+
+func (e *findingsError) Error() string {
+	return e.message
+}
+
+// findings returns a findingsError with a formatted message
+func findings(format string, args ...any) error {
+	return &findingsError{message: fmt.Sprintf(format, args...)}
+}
+
+// exitCode maps an error returned by a command to its exit code
+func exitCode(err error) int {
+	var f *findingsError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &f):
+		return exitFindings
+	default:
+		return exitError
 	}
+}
 
-	if *output == "" {
-		*output = baseName + ".md"
+// reportError prints err, if any, and returns the matching exit code
+func reportError(err error) int {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 	}
+	return exitCode(err)
+}
 
-	openapi, err := os.ReadFile(inputFile)
+// convertSpec reads and converts the named spec
+func convertSpec(input string, opts conv.ConvertOptions) (*conv.ConvertResult, error) {
+	openapi, err := readInput(input)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("reading %s: %w", input, err)
 	}
 
-	result, err := conv.Convert(openapi, conv.ConvertOptions{
-		Title:               *title,
-		Description:         *description,
-		EnableSharedSchemas: *sharedSchemas,
-	})
+	result, err := conv.Convert(openapi, opts)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error converting: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("converting %s: %w", input, err)
 	}
 
-	if err := os.WriteFile(*output, result.Markdown, 0644); err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
-		os.Exit(1)
+	return result, nil
+}
+
+// printWarnings prints the conversion warnings of the named spec, prefixed with its path so
+// that warnings from several specs can be told apart
+func printWarnings(w io.Writer, input string, result *conv.ConvertResult) {
+	prefix := ""
+	if input != stdio {
+		prefix = input + ": "
+	}
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(w, "%sWarning: %s\n", prefix, warning)
+	}
+}
+
+// specJobs returns one job per spec file named on the command line or, without arguments, in
+// the jobs of the configuration file. Titles default to the spec file name.
+func specJobs(fs *flag.FlagSet, flags *convertFlags) ([]jobConfig, error) {
+	jobs, err := flags.jobs(fs)
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	var specs []jobConfig
+	for _, job := range jobs {
+		if job.Input == "" && len(job.Inputs) == 0 {
+			continue
+		}
+
+		files := []string{job.Input}
+		if job.isBatch() {
+			if files, err = job.specFiles(); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, file := range files {
+			spec := job
+			spec.Inputs = nil
+			spec.Input = file
+			if spec.Title == "" {
+				spec.Title = specBaseName(file)
+			}
+			specs = append(specs, spec)
+		}
+	}
+
+	return specs, nil
+}
+
+// writeOutput writes content to path, or to stdout for "-". In check mode it compares content
+// with the existing file instead and fails with a diff when it is out of date.
+func writeOutput(path, source string, content []byte, check bool) error {
+	if check {
+		diff, err := checkOutput(path, content)
+		if err != nil {
+			return fmt.Errorf("checking %s: %w", path, err)
+		}
+		if diff != "" {
+			fmt.Fprint(stdout, diff)
+			return findings("%s is out of date, regenerate it from %s", path, source)
+		}
+		fmt.Fprintf(os.Stderr, "%s is up to date\n", path)
+		return nil
+	}
+
+	if path == stdio {
+		if _, err := stdout.Write(content); err != nil {
+			return fmt.Errorf("writing output: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", path)
+	return nil
+}
+
+// stdio is the file name that stands for stdin as input and stdout as output
+const stdio = "-"
+
+// stdin and stdout are read and written for the stdio file name
+var (
+	stdin  io.Reader = os.Stdin
+	stdout io.Writer = os.Stdout
+)
+
+// readInput reads the spec from the named file, or from stdin when name is "-"
+func readInput(name string) ([]byte, error) {
+	if name == stdio {
+		return io.ReadAll(stdin)
 	}
-	fmt.Fprintf(os.Stderr, "Wrote %s\n", *output)
+	return os.ReadFile(name)
 }
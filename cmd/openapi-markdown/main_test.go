@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// documentedSpec passes every lint rule
+const documentedSpec = `openapi: 3.0.0
+info:
+  title: Pet API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      summary: List pets
+      responses:
+        '204':
+          description: Listed
+        '400':
+          description: Invalid request
+`
+
+// undocumentedSpec has an operation without a summary or description
+const undocumentedSpec = `openapi: 3.0.0
+info:
+  title: Pet API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      responses:
+        '204':
+          description: Listed
+        '400':
+          description: Invalid request
+`
+
+func TestRunExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	documented := filepath.Join(dir, "documented.yaml")
+	undocumented := filepath.Join(dir, "undocumented.yaml")
+	missing := filepath.Join(dir, "missing.yaml")
+	output := filepath.Join(dir, "documented.md")
+	require.NoError(t, os.WriteFile(documented, []byte(documentedSpec), 0o644))
+	require.NoError(t, os.WriteFile(undocumented, []byte(undocumentedSpec), 0o644))
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("openapi: 3.0.0\npaths: [\n"), 0o644))
+	docs := filepath.Join(dir, "docs")
+
+	for _, test := range []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "help", args: []string{"help"}, want: exitOK},
+		{name: "command help", args: []string{"lint", "-h"}, want: exitOK},
+		{name: "unknown flag", args: []string{"stats", "-unknown"}, want: exitError},
+		{name: "convert without command", args: []string{"-o", output, documented}, want: exitOK},
+		{name: "convert up to date", args: []string{"convert", "-check", "-o", output, documented}, want: exitOK},
+		{name: "convert stale", args: []string{"convert", "-check", "-o", output, undocumented}, want: exitFindings},
+		{name: "convert missing spec", args: []string{"convert", "-o", output, missing}, want: exitError},
+		{name: "convert without spec", args: []string{"convert"}, want: exitError},
+		{name: "batch", args: []string{"-o", docs, documented, undocumented}, want: exitOK},
+		{name: "batch with an invalid spec", args: []string{"-o", docs, documented, invalid}, want: exitError},
+		{name: "lint clean", args: []string{"lint", documented}, want: exitOK},
+		{name: "lint findings", args: []string{"lint", undocumented}, want: exitFindings},
+		{name: "lint rule off", args: []string{"lint", "-rule", "operation-description=off", undocumented}, want: exitOK},
+		{name: "lint unsupported format", args: []string{"lint", "-format", "xml", documented}, want: exitError},
+		{name: "lint missing spec", args: []string{"lint", missing}, want: exitError},
+		{name: "diff identical", args: []string{"diff", documented, documented}, want: exitOK},
+		{name: "diff different", args: []string{"diff", documented, undocumented}, want: exitFindings},
+		{name: "diff one spec", args: []string{"diff", documented}, want: exitError},
+		{name: "diff missing spec", args: []string{"diff", documented, missing}, want: exitError},
+		{name: "stats", args: []string{"stats", documented}, want: exitOK},
+		{name: "stats json", args: []string{"stats", "-format", "json", documented}, want: exitOK},
+		{name: "stats unsupported format", args: []string{"stats", "-format", "xml", documented}, want: exitError},
+		{name: "stats missing spec", args: []string{"stats", missing}, want: exitError},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var code int
+			captureOutput(t, &os.Stdout, func() {
+				captureOutput(t, &os.Stderr, func() {
+					code = run(test.args)
+				})
+			})
+			assert.Equal(t, test.want, code)
+		})
+	}
+}
+
+func TestReadInput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("from file"), 0o644))
+	useStdio(t, "from stdin")
+
+	for _, test := range []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "stdin", input: stdio, want: "from stdin"},
+		{name: "file", input: path, want: "from file"},
+		{name: "missing file", input: path + ".missing", wantErr: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := readInput(test.input)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, string(data))
+		})
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	out := useStdio(t, "")
+
+	require.NoError(t, writeOutput(stdio, "openapi.yaml", []byte("# API\n"), false))
+	assert.Equal(t, "# API\n", out.String())
+
+	path := filepath.Join(t.TempDir(), "api.md")
+	captureOutput(t, &os.Stderr, func() {
+		require.NoError(t, writeOutput(path, "openapi.yaml", []byte("# File\n"), false))
+	})
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# File\n", string(data))
+	assert.Equal(t, "# API\n", out.String())
+}
+
+func TestRunStdio(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantOut  string
+	}{
+		{
+			name:     "stdin to stdout",
+			args:     []string{"-"},
+			wantCode: exitOK,
+			wantOut:  "# API\n\n## Table of Contents\n",
+		},
+		{
+			name:     "explicit stdout",
+			args:     []string{"-o", "-", "-title", "Pet API", "-"},
+			wantCode: exitOK,
+			wantOut:  "# Pet API\n\n## Table of Contents\n",
+		},
+		{
+			name:     "check requires an output file",
+			args:     []string{"-check", "-"},
+			wantCode: exitError,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out := useStdio(t, documentedSpec)
+
+			var code int
+			captureOutput(t, &os.Stderr, func() {
+				code = run(test.args)
+			})
+
+			assert.Equal(t, test.wantCode, code)
+			if test.wantOut == "" {
+				assert.Empty(t, out.String())
+				return
+			}
+			assert.True(t, strings.HasPrefix(out.String(), test.wantOut), out.String())
+			assert.Contains(t, out.String(), "GET [/pets](#getpets) | List pets")
+		})
+	}
+}
+
+// useStdio replaces stdin with input and stdout with the returned buffer for the test
+func useStdio(t *testing.T, input string) *bytes.Buffer {
+	t.Helper()
+
+	originalIn, originalOut := stdin, stdout
+	t.Cleanup(func() { stdin, stdout = originalIn, originalOut })
+
+	var out bytes.Buffer
+	stdin, stdout = strings.NewReader(input), &out
+	return &out
+}
+
+func TestPrintWarnings(t *testing.T) {
+	result := &conv.ConvertResult{Warnings: []conv.Warning{
+		{Code: conv.WarningMissingDescription, Message: "GET /pets has no summary or description", Pointer: "/paths/~1pets/get"},
+		{Code: conv.WarningAnchorCollision, Message: "anchor getpets is already used"},
+	}}
+
+	for _, test := range []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "spec file",
+			input: "specs/pets.yaml",
+			want: "specs/pets.yaml: Warning: /paths/~1pets/get: GET /pets has no summary or description\n" +
+				"specs/pets.yaml: Warning: anchor getpets is already used\n",
+		},
+		{
+			name:  "stdin",
+			input: stdio,
+			want: "Warning: /paths/~1pets/get: GET /pets has no summary or description\n" +
+				"Warning: anchor getpets is already used\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			printWarnings(&out, test.input, result)
+			assert.Equal(t, test.want, out.String())
+		})
+	}
+}
+
+// captureOutput returns what fn writes to the standard stream file points at, such as os.Stderr
+func captureOutput(t *testing.T, file **os.File, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	original := *file
+	*file = w
+	defer func() { *file = original }()
+
+	out := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- string(data)
+	}()
+
+	fn()
+	require.NoError(t, w.Close())
+	return <-out
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"html/template"
+	"net/http"
+	"os"
+	"slices"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+)
+
+// reloadInterval is how often the preview page asks the server whether the spec changed, in milliseconds
+const reloadInterval = 1000
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 980px; margin: 0 auto; padding: 32px; line-height: 1.5; color: #1f2328; }
+pre { background: #f6f8fa; padding: 16px; overflow: auto; border-radius: 6px; }
+code { background: #f6f8fa; padding: 0.2em 0.4em; border-radius: 6px; font-size: 85%; }
+pre code { padding: 0; font-size: 100%; }
+table { border-collapse: collapse; margin-bottom: 16px; }
+th, td { border: 1px solid #d1d9e0; padding: 6px 13px; text-align: left; }
+blockquote { margin: 0 0 16px; padding: 0 1em; color: #59636e; border-left: 0.25em solid #d1d9e0; }
+.error { color: #d1242f; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+{{if .Error}}<pre class="error">{{.Error}}</pre>{{else}}{{.Body}}{{end}}
+<script>
+const version = {{.Version}};
+setInterval(async () => {
+	try {
+		const response = await fetch("/version");
+		if (await response.text() !== version) {
+			location.reload();
+		}
+	} catch (e) {}
+}, {{.ReloadInterval}});
+</script>
+</body>
+</html>
+`))
+
+// page is the data rendered by pageTemplate
+type page struct {
+	Title          string
+	Body           template.HTML
+	Error          string
+	Version        string
+	ReloadInterval int
+}
+
+// runServe implements the serve command, which previews the converted documentation as HTML
+// and reloads the page whenever the spec or a file it references changes
+func runServe(args []string) int {
+	fs := newFlagSet("serve", "[flags] openapi-file", `Serves the converted documentation as HTML on a local address and reloads
+the page when the spec changes.`)
+	var flags convertFlags
+	flags.register(fs)
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	if err := fs.Parse(args); err != nil {
+		return parseExitCode(err)
+	}
+
+	jobs, err := flags.jobs(fs)
+	if err != nil {
+		return reportError(fmt.Errorf("loading config: %w", err))
+	}
+	if len(jobs) != 1 || jobs[0].Input == "" || jobs[0].Input == stdio || jobs[0].isBatch() {
+		fs.Usage()
+		return exitError
+	}
+
+	job := jobs[0]
+	if job.Title == "" {
+		job.Title = specBaseName(job.Input)
+	}
+	// The preview gives headings GitHub-style ids, so operation links must use the same anchors
+	if job.Anchors == "" {
+		job.Anchors = string(conv.AnchorGitHub)
+	}
+
+	fmt.Fprintf(os.Stderr, "Serving %s on http://%s\n", job.Input, *addr)
+	return reportError(http.ListenAndServe(*addr, previewHandler(job)))
+}
+
+// previewHandler serves the converted spec as an HTML page at / and its current version,
+// which the page polls to reload itself, at /version
+func previewHandler(job jobConfig) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, req *http.Request) {
+		p := page{Title: job.Title, Version: specVersion(job), ReloadInterval: reloadInterval}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		result, err := convertSpec(job.Input, job.options())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			p.Error = err.Error()
+		} else {
+			printWarnings(os.Stderr, job.Input, result)
+			p.Body = template.HTML(markdownToHTML(result.Markdown))
+		}
+
+		if err := pageTemplate.Execute(w, p); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: rendering page: %v\n", err)
+		}
+	})
+	mux.HandleFunc("GET /version", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, specVersion(job))
+	})
+	return mux
+}
+
+// specVersion fingerprints the modification times of the spec and the files it references
+func specVersion(job jobConfig) string {
+	files := snapshot([]jobConfig{job})
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	hash := fnv.New64a()
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s %d\n", name, files[name].UnixNano())
+	}
+	return fmt.Sprintf("%x", hash.Sum64())
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const previewSpec = `openapi: 3.0.0
+info:
+  title: Pet API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      summary: List pets
+      responses:
+        '204':
+          description: Listed
+`
+
+func TestPreviewHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(previewSpec), 0o644))
+
+	server := httptest.NewServer(previewHandler(jobConfig{Input: path, Title: "Pet API", Anchors: "github"}))
+	defer server.Close()
+
+	get := func(path string) (int, string, string) {
+		t.Helper()
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, resp.Header.Get("Content-Type"), string(body)
+	}
+
+	status, contentType, page := get("/")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "text/html; charset=utf-8", contentType)
+	assert.Contains(t, page, "<title>Pet API</title>")
+	assert.Contains(t, page, `<h1 id="pet-api">Pet API</h1>`)
+	assert.Contains(t, page, `<a href="#get-pets">/pets</a>`)
+
+	status, contentType, version := get("/version")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "text/plain; charset=utf-8", contentType)
+	assert.NotEmpty(t, version)
+	assert.Contains(t, page, `const version = "`+version+`";`)
+
+	_, _, unchanged := get("/version")
+	assert.Equal(t, version, unchanged)
+
+	// A newer modification time changes the version, and an invalid spec renders its error
+	require.NoError(t, os.WriteFile(path, []byte("openapi: 3.0.0\npaths: [\n"), 0o644))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	_, _, changed := get("/version")
+	assert.NotEqual(t, version, changed)
+
+	status, _, page = get("/")
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Contains(t, page, `<pre class="error">`)
+
+	status, _, _ = get("/missing")
+	assert.Equal(t, http.StatusNotFound, status)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+)
+
+// statsFormats are the output formats of the stats command
+var statsFormats = []string{"text", "json"}
+
+// specStats is the JSON output format of the stats command
+type specStats struct {
+	File            string         `json:"file"`
+	Operations      int            `json:"operations"`
+	Tags            int            `json:"tags"`
+	Untagged        int            `json:"untagged"`
+	RequestBodies   int            `json:"requestBodies"`
+	SharedSchemas   int            `json:"sharedSchemas"`
+	ParameterCounts map[string]int `json:"parameters"`
+	ResponseCounts  map[string]int `json:"responses"`
+	Coverage        conv.Stats     `json:"coverage"`
+}
+
+// runStats implements the stats command, which prints a summary of the operations,
+// parameters, responses and schemas documented for each spec and their documentation coverage
+func runStats(args []string) int {
+	fs := newFlagSet("stats", "[flags] [openapi-file...]", `Prints a summary of the operations, parameters, responses and schemas that
+end up in the documentation of each spec, and the percentage of them with
+descriptions and examples by tag and schema. Filters and audience flags apply.`)
+	var flags convertFlags
+	flags.register(fs)
+	format := fs.String("format", "text", "output format: "+strings.Join(statsFormats, ", "))
+	if err := fs.Parse(args); err != nil {
+		return parseExitCode(err)
+	}
+
+	if !slices.Contains(statsFormats, *format) {
+		return reportError(fmt.Errorf("unsupported format: %s", *format))
+	}
+
+	specs, err := specJobs(fs, &flags)
+	if err != nil {
+		return reportError(err)
+	}
+	if len(specs) == 0 {
+		fs.Usage()
+		return exitError
+	}
+
+	code := exitOK
+	var all []specStats
+	for i, spec := range specs {
+		options := spec.options()
+		options.Debug = true
+
+		result, err := convertSpec(spec.Input, options)
+		if err != nil {
+			code = max(code, reportError(err))
+			continue
+		}
+
+		if *format == "json" {
+			all = append(all, specStats{
+				File:            spec.Input,
+				Operations:      result.EndpointCount,
+				Tags:            result.TagCount,
+				Untagged:        result.Debug.UntaggedOps,
+				RequestBodies:   result.Debug.RequestBodyCount,
+				SharedSchemas:   result.Debug.SharedSchemaCount,
+				ParameterCounts: result.Debug.ParameterCounts,
+				ResponseCounts:  result.Debug.ResponseCounts,
+				Coverage:        result.Debug.Stats,
+			})
+			continue
+		}
+
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s\n", spec.Input)
+		fmt.Printf("  Operations:     %d\n", result.EndpointCount)
+		fmt.Printf("  Tags:           %d\n", result.TagCount)
+		fmt.Printf("  Untagged:       %d\n", result.Debug.UntaggedOps)
+		fmt.Printf("  Request bodies: %d\n", result.Debug.RequestBodyCount)
+		fmt.Printf("  Shared schemas: %d\n", result.Debug.SharedSchemaCount)
+		fmt.Printf("  Parameters:     %s\n", formatCounts(result.Debug.ParameterCounts))
+		fmt.Printf("  Responses:      %s\n", formatCounts(result.Debug.ResponseCounts))
+		printCoverage(result.Debug.Stats)
+	}
+
+	if *format == "json" {
+		if err := writeJSON(all); err != nil {
+			return reportError(err)
+		}
+	}
+
+	return code
+}
+
+// printCoverage prints the described and example percentages overall, by tag and by schema
+func printCoverage(stats conv.Stats) {
+	fmt.Printf("\n  Coverage (described / examples)\n")
+	printCoverageRow("    Operations", stats.Operations)
+	printCoverageRow("    Parameters", stats.Parameters)
+	printCoverageRow("    Fields", stats.Fields)
+	printCoverageRow("    Responses", stats.Responses)
+
+	if len(stats.ByTag) > 0 {
+		fmt.Printf("\n  By tag (operations, parameters, fields, responses)\n")
+		for _, tag := range slices.Sorted(maps.Keys(stats.ByTag)) {
+			c := stats.ByTag[tag]
+			row := fmt.Sprintf("    %-22s %s  %s  %s  %s", tag,
+				formatCoverage(c.Operations), formatCoverage(c.Parameters), formatCoverage(c.Fields), formatCoverage(c.Responses))
+			fmt.Println(strings.TrimRight(row, " "))
+		}
+	}
+
+	if len(stats.BySchema) > 0 {
+		fmt.Printf("\n  By schema (fields)\n")
+		for _, name := range slices.Sorted(maps.Keys(stats.BySchema)) {
+			printCoverageRow("    "+name, stats.BySchema[name])
+		}
+	}
+}
+
+func printCoverageRow(label string, c conv.Coverage) {
+	fmt.Printf("%-26s %s  (%d items)\n", label, formatCoverage(c), c.Total)
+}
+
+// formatCoverage formats a coverage as "described% / examples%", with "-" when there is nothing to measure
+func formatCoverage(c conv.Coverage) string {
+	return fmt.Sprintf("%4s / %-4s", formatPercent(c.Described, c.Total, c.DescribedPercent()),
+		formatPercent(c.Examples, c.ExampleTotal, c.ExamplesPercent()))
+}
+
+func formatPercent(n, total int, pct float64) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", pct)
+}
+
+// formatCounts formats a count per key as "key: n" pairs in key order
+func formatCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "none"
+	}
+
+	var pairs []string
+	for _, key := range slices.Sorted(maps.Keys(counts)) {
+		pairs = append(pairs, fmt.Sprintf("%s: %d", key, counts[key]))
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// watchInterval is how often watched files are polled for changes
+const watchInterval = 500 * time.Millisecond
+
+// watchJobs runs every job, then polls the input specs and the files they reference and runs
+// the jobs again whenever one of them changes. It only returns on error.
+func watchJobs(jobs []jobConfig) error {
+	for _, job := range jobs {
+		if job.Input == stdio || job.Output == stdio {
+			return fmt.Errorf("-watch cannot be used with stdin or stdout")
+		}
+	}
+
+	for {
+		start := time.Now()
+		for _, job := range jobs {
+			if err := runJob(job, false); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Regenerated in %s, watching for changes\n", time.Since(start).Round(time.Millisecond))
+
+		last := snapshot(jobs)
+		for {
+			time.Sleep(watchInterval)
+			if current := snapshot(jobs); !maps.Equal(current, last) {
+				break
+			}
+		}
+	}
+}
+
+// snapshot returns the modification time of every file the jobs read. Missing files are
+// recorded with a zero time so that creating them counts as a change.
+func snapshot(jobs []jobConfig) map[string]time.Time {
+	files := make(map[string]time.Time)
+	for _, job := range jobs {
+		specs := []string{job.Input}
+		if job.isBatch() {
+			// Expand again on every poll so new specs matching a pattern are picked up
+			specs, _ = job.specFiles()
+		}
+		for _, spec := range specs {
+			collectReferencedFiles(spec, files)
+		}
+	}
+	return files
+}
+
+// collectReferencedFiles records path and, recursively, every file it references through a
+// relative $ref
+func collectReferencedFiles(path string, files map[string]time.Time) {
+	path = filepath.Clean(path)
+	if _, ok := files[path]; ok {
+		return
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		files[path] = time.Time{}
+		return
+	}
+	files[path] = info.ModTime()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return
+	}
+
+	for _, ref := range externalRefs(&node) {
+		if !filepath.IsAbs(ref) {
+			ref = filepath.Join(filepath.Dir(path), ref)
+		}
+		collectReferencedFiles(ref, files)
+	}
+}
+
+// externalRefs returns the file part of every $ref in node that points outside the document.
+// Remote (URL) references are ignored.
+func externalRefs(node *yaml.Node) []string {
+	var refs []string
+
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value != "$ref" || value.Kind != yaml.ScalarNode {
+				continue
+			}
+			file, _, _ := strings.Cut(value.Value, "#")
+			if file != "" && !strings.Contains(file, "://") {
+				refs = append(refs, file)
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		refs = append(refs, externalRefs(child)...)
+	}
+
+	return refs
+}
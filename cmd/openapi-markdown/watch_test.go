@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"openapi.yaml": `openapi: 3.0.0
+paths:
+  /users:
+    $ref: "paths/users.yaml#/users"
+  /orders:
+    $ref: "https://example.com/orders.yaml"
+components:
+  schemas:
+    Local:
+      $ref: "#/components/schemas/Other"
+    Missing:
+      $ref: "missing.yaml"
+`,
+		"paths/users.yaml": `users:
+  get:
+    responses:
+      "200":
+        $ref: "../schemas/user.yaml"
+`,
+		"schemas/user.yaml": `type: object
+properties:
+  self:
+    $ref: "user.yaml"
+`,
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	files := snapshot([]jobConfig{{Input: filepath.Join(dir, "openapi.yaml")}})
+
+	var names []string
+	for name := range files {
+		rel, err := filepath.Rel(dir, name)
+		require.NoError(t, err)
+		names = append(names, filepath.ToSlash(rel))
+	}
+	slices.Sort(names)
+	assert.Equal(t, []string{"missing.yaml", "openapi.yaml", "paths/users.yaml", "schemas/user.yaml"}, names)
+	assert.Equal(t, time.Time{}, files[filepath.Join(dir, "missing.yaml")])
+	assert.False(t, files[filepath.Join(dir, "schemas", "user.yaml")].IsZero())
+}
@@ -1,18 +1,22 @@
 package conv
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
-	"log"
-	"regexp"
+	"io/fs"
+	"slices"
 	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	proto "github.com/duh-rpc/openapi-schema.go"
 	"github.com/pb33f/libopenapi"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
 )
 
 // ConvertResult contains markdown output and generation metadata
@@ -20,7 +24,7 @@ type ConvertResult struct {
 	Markdown      []byte
 	EndpointCount int
 	TagCount      int
-	Warnings      []string
+	Warnings      []Warning
 	Debug         *DebugInfo
 }
 
@@ -35,17 +39,85 @@ type DebugInfo struct {
 	RequestBodyCount  int
 	SharedSchemaCount int
 	NestedSchemaDepth map[string]int
+	// Stats is the documentation coverage of the converted operations
+	Stats Stats
 }
 
+// TOCStyle controls how the table of contents is rendered
+type TOCStyle string
+
+const (
+	// TOCTable renders the table of contents as HTTP Request | Description tables (default)
+	TOCTable TOCStyle = "table"
+	// TOCList renders the table of contents as a compact nested bullet list
+	TOCList TOCStyle = "list"
+)
+
+// DeprecatedMode controls how operations marked `deprecated: true` are rendered
+type DeprecatedMode string
+
+const (
+	// DeprecatedInline renders deprecated operations in place with a deprecation notice (default)
+	DeprecatedInline DeprecatedMode = "inline"
+	// DeprecatedHide omits deprecated operations from the output entirely
+	DeprecatedHide DeprecatedMode = "hide"
+	// DeprecatedSection moves deprecated operations into a "Deprecated" section at the end
+	DeprecatedSection DeprecatedMode = "section"
+)
+
 // ConvertOptions configures markdown generation
 type ConvertOptions struct {
 	EnableSharedSchemas bool
 	Description         string
 	Title               string
 	Debug               bool
+	TOCStyle            TOCStyle
+	Deprecated          DeprecatedMode
+	// Include keeps only operations matching every criterion set in the filter
+	Include OperationFilter
+	// Exclude drops operations matching any criterion set in the filter
+	Exclude OperationFilter
+	// ExcludeAudiences removes operations, parameters, responses and schema properties whose
+	// audience extension holds one of these values (e.g. "internal" for an external build)
+	ExcludeAudiences []string
+	// AudienceExtension names the vendor extension holding audience values (default "x-audience")
+	AudienceExtension string
+	// HeadingOffset shifts every generated heading down by this many levels, for embedding the
+	// output in a larger document (e.g. 1 renders the title as "##")
+	HeadingOffset int
+	// Anchors selects how operation anchors are generated
+	Anchors AnchorStrategy
+	// AnchorFunc, when set, generates operation anchors and takes precedence over Anchors.
+	// Returning an empty string falls back to the configured strategy.
+	AnchorFunc func(method, path, operationID string) string
+	// BasePath is the directory relative $ref file references (e.g. "./schemas/pet.yaml#/Pet")
+	// are resolved from, typically the directory containing the spec
+	BasePath string
+	// FS resolves relative $ref file references from a file system instead of BasePath. Paths are
+	// relative to the root of FS.
+	FS fs.FS
+	// Strict makes Convert fail, listing the location of each construct the documentation would
+	// silently leave out: cookie and path-level parameters, media types other than JSON and
+	// forms, default responses, response headers, anyOf, schema references outside
+	// #/components/schemas/ and schemas that cannot be resolved
+	Strict bool
+	// WarningsAsErrors makes Convert fail with a *WarningsError when the conversion raises warnings
+	WarningsAsErrors bool
+	// CodeSampleLanguages selects the languages of the request samples generated for each
+	// operation, in order (default curl only)
+	CodeSampleLanguages []CodeSampleLanguage
+	// MaxExamples caps the number of named examples rendered for each request body and
+	// response (0 renders them all)
+	MaxExamples int
+	// DisableCodeSamples omits the generated request samples. Hand-written samples from the
+	// x-codeSamples extension are still rendered.
+	DisableCodeSamples bool
 }
 
-// Convert converts OpenAPI 3.x to markdown API documentation
+// defaultTag is the section name for operations without tags
+const defaultTag = "Default APIs"
+
+// Convert converts OpenAPI 3.x or Swagger 2.0 to markdown API documentation
 func Convert(openapi []byte, opts ConvertOptions) (*ConvertResult, error) {
 	if len(openapi) == 0 {
 		return nil, fmt.Errorf("openapi input cannot be empty")
@@ -55,47 +127,84 @@ func Convert(openapi []byte, opts ConvertOptions) (*ConvertResult, error) {
 		return nil, fmt.Errorf("title cannot be empty")
 	}
 
-	doc, err := libopenapi.NewDocument(openapi)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse openapi document: %w", err)
+	switch opts.TOCStyle {
+	case "", TOCTable, TOCList:
+	default:
+		return nil, fmt.Errorf("unsupported toc style: %s", opts.TOCStyle)
 	}
 
-	if doc.GetVersion() == "" {
-		return nil, fmt.Errorf("failed to determine openapi version")
+	switch opts.Deprecated {
+	case "", DeprecatedInline, DeprecatedHide, DeprecatedSection:
+	default:
+		return nil, fmt.Errorf("unsupported deprecated mode: %s", opts.Deprecated)
 	}
 
-	if !strings.HasPrefix(doc.GetVersion(), "3.") {
-		return nil, fmt.Errorf("only openapi 3.x is supported, got version: %s", doc.GetVersion())
+	if opts.HeadingOffset < 0 {
+		return nil, fmt.Errorf("heading offset cannot be negative")
+	}
+	if deepestHeading+opts.HeadingOffset > 6 {
+		return nil, fmt.Errorf("heading offset %d pushes headings past level 6, the maximum is %d",
+			opts.HeadingOffset, 6-deepestHeading)
 	}
 
-	v3Model, err := doc.BuildV3Model()
-	if err != nil {
-		return nil, fmt.Errorf("failed to build openapi 3.x model: %w", err)
+	if opts.MaxExamples < 0 {
+		return nil, fmt.Errorf("max examples cannot be negative")
 	}
 
-	if v3Model == nil {
-		return nil, fmt.Errorf("only openapi 3.x is supported")
+	switch opts.Anchors {
+	case "", AnchorCompact, AnchorOperationID, AnchorGitHub:
+	default:
+		return nil, fmt.Errorf("unsupported anchor strategy: %s", opts.Anchors)
+	}
+
+	for _, lang := range opts.CodeSampleLanguages {
+		if _, ok := codeSampleGenerators[lang]; !ok {
+			return nil, fmt.Errorf("unsupported code sample language: %s", lang)
+		}
+	}
+
+	source := openapi
+	model, openapi, err := loadModel(openapi, opts)
+	if err != nil {
+		return nil, err
 	}
+	// Line numbers in the model only match the input when it was parsed as given
+	rewritten := !bytes.Equal(source, openapi)
 
 	examples, err := generateComponentExamples(openapi)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate component examples: %w", err)
 	}
 
-	endpoints := extractEndpoints(v3Model.Model)
-	tagGroups := groupByTags(endpoints)
-	sharedSchemas := identifySharedSchemas(endpoints)
+	endpoints := selectEndpoints(*model, opts)
+	if opts.Strict {
+		if err := checkStrict(*model, endpoints, opts, rewritten); err != nil {
+			return nil, fmt.Errorf("strict mode, the documentation would leave out:\n%w", err)
+		}
+	}
+
+	active, deprecated := endpoints, []endpoint(nil)
+	if opts.Deprecated == DeprecatedSection {
+		active, deprecated = splitDeprecated(endpoints)
+	}
+
+	tagGroups := groupByTags(active)
+	sharedSchemas := identifySharedSchemas(endpoints, opts)
 
 	markdownSharedSchemas := map[string]schemaUsage{}
 	if opts.EnableSharedSchemas {
 		markdownSharedSchemas = sharedSchemas
 	}
 
-	markdown, warnings, err := generateMarkdown(opts, endpoints, tagGroups, examples, markdownSharedSchemas, v3Model.Model)
+	markdown, warnings, err := generateMarkdown(opts, active, deprecated, tagGroups, examples, markdownSharedSchemas, *model, rewritten)
 	if err != nil {
 		return nil, err
 	}
 
+	if opts.WarningsAsErrors && len(warnings) > 0 {
+		return nil, &WarningsError{Warnings: warnings}
+	}
+
 	result := &ConvertResult{
 		Markdown:      []byte(markdown),
 		EndpointCount: len(endpoints),
@@ -104,21 +213,93 @@ func Convert(openapi []byte, opts ConvertOptions) (*ConvertResult, error) {
 	}
 
 	if opts.Debug {
-		result.Debug = collectDebugInfo(v3Model.Model, endpoints, tagGroups, sharedSchemas)
+		result.Debug = collectDebugInfo(*model, endpoints, tagGroups, sharedSchemas, opts)
 	}
 
 	return result, nil
 }
 
+// loadModel upgrades Swagger 2.0 input, bundles external references and builds the OpenAPI 3
+// model. The bundled document is returned alongside the model.
+func loadModel(openapi []byte, opts ConvertOptions) (*v3.Document, []byte, error) {
+	openapi, err := upgradeSwagger(openapi)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	openapi, err = bundleExternalRefs(openapi, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc, err := libopenapi.NewDocument(openapi)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse openapi document: %w", err)
+	}
+
+	if doc.GetVersion() == "" {
+		return nil, nil, fmt.Errorf("failed to determine openapi version")
+	}
+
+	if !strings.HasPrefix(doc.GetVersion(), "3.") {
+		return nil, nil, fmt.Errorf("only openapi 3.x and swagger 2.0 are supported, got version: %s", doc.GetVersion())
+	}
+
+	v3Model, err := doc.BuildV3Model()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build openapi 3.x model: %w", err)
+	}
+
+	if v3Model == nil {
+		return nil, nil, fmt.Errorf("only openapi 3.x is supported")
+	}
+
+	return &v3Model.Model, openapi, nil
+}
+
+// selectEndpoints returns the operations left after applying the filters, audience exclusions
+// and deprecated handling of opts
+func selectEndpoints(model v3.Document, opts ConvertOptions) []endpoint {
+	endpoints := filterEndpoints(extractEndpoints(model), opts.Include, opts.Exclude)
+	endpoints = filterAudience(endpoints, opts)
+	if opts.Deprecated == DeprecatedHide {
+		endpoints, _ = splitDeprecated(endpoints)
+	}
+	return endpoints
+}
+
 type endpoint struct {
 	method      string
 	path        string
 	summary     string
 	description string
 	tags        []string
+	deprecated  bool
 	operation   *v3.Operation
 }
 
+// renderer holds the options and spec-wide state shared by the markdown rendering functions
+type renderer struct {
+	opts          ConvertOptions
+	examples      map[string]json.RawMessage
+	sharedSchemas map[string]schemaUsage
+	model         v3.Document
+	// rewritten reports that the spec was rewritten before parsing (Swagger 2.0 upgrade or
+	// bundled external references), so model line numbers do not match the input
+	rewritten bool
+
+	// anchors maps each operation key to its anchor, explicitAnchors records which anchors
+	// need an HTML anchor tag because the heading does not produce them
+	anchors         map[string]string
+	explicitAnchors map[string]bool
+
+	warnings []Warning
+	// operation and pointer identify the operation being rendered (e.g. "GET /pets" and
+	// "/paths/~1pets/get") so warnings can be attributed to it
+	operation string
+	pointer   string
+}
+
 // schemaField represents information about a single field in a schema
 type schemaField struct {
 	name            string
@@ -170,6 +351,10 @@ func extractEndpoints(model v3.Document) []endpoint {
 				e.tags = op.Tags
 			}
 
+			if op.Deprecated != nil && *op.Deprecated {
+				e.deprecated = true
+			}
+
 			endpoints = append(endpoints, e)
 		}
 	}
@@ -177,12 +362,27 @@ func extractEndpoints(model v3.Document) []endpoint {
 	return endpoints
 }
 
+// splitDeprecated separates active operations from those marked deprecated
+func splitDeprecated(endpoints []endpoint) ([]endpoint, []endpoint) {
+	var active, deprecated []endpoint
+
+	for _, e := range endpoints {
+		if e.deprecated {
+			deprecated = append(deprecated, e)
+		} else {
+			active = append(active, e)
+		}
+	}
+
+	return active, deprecated
+}
+
 func groupByTags(endpoints []endpoint) map[string][]endpoint {
 	tagGroups := make(map[string][]endpoint)
 
 	for _, e := range endpoints {
 		if len(e.tags) == 0 {
-			tagGroups["Default APIs"] = append(tagGroups["Default APIs"], e)
+			tagGroups[defaultTag] = append(tagGroups[defaultTag], e)
 		} else {
 			for _, tag := range e.tags {
 				tagGroups[tag] = append(tagGroups[tag], e)
@@ -193,15 +393,17 @@ func groupByTags(endpoints []endpoint) map[string][]endpoint {
 	return tagGroups
 }
 
-// identifySharedSchemas finds schemas used in multiple endpoints
-func identifySharedSchemas(endpoints []endpoint) map[string]schemaUsage {
+// identifySharedSchemas finds schemas used in multiple endpoints, ignoring request bodies
+// and responses hidden from the rendered audience
+func identifySharedSchemas(endpoints []endpoint, opts ConvertOptions) map[string]schemaUsage {
 	schemaToEndpoints := make(map[string]map[string]bool)
 
 	for _, e := range endpoints {
 		endpointKey := e.method + " " + e.path
 
 		// Extract request body schema if exists
-		if e.operation != nil && e.operation.RequestBody != nil && e.operation.RequestBody.Content != nil {
+		if e.operation != nil && e.operation.RequestBody != nil && e.operation.RequestBody.Content != nil &&
+			!isExcludedAudience(opts, e.operation.RequestBody.Extensions) {
 			for pair := e.operation.RequestBody.Content.First(); pair != nil; pair = pair.Next() {
 				if pair.Key() != "application/json" {
 					continue
@@ -229,7 +431,7 @@ func identifySharedSchemas(endpoints []endpoint) map[string]schemaUsage {
 		if e.operation != nil && e.operation.Responses != nil && e.operation.Responses.Codes != nil {
 			for pair := e.operation.Responses.Codes.First(); pair != nil; pair = pair.Next() {
 				resp := pair.Value()
-				if resp == nil || resp.Content == nil {
+				if resp == nil || resp.Content == nil || isExcludedAudience(opts, resp.Extensions) {
 					continue
 				}
 
@@ -277,43 +479,25 @@ func identifySharedSchemas(endpoints []endpoint) map[string]schemaUsage {
 	return sharedSchemas
 }
 
-func makeAnchor(method, path string) string {
-	combined := method + " " + path
-	combined = strings.ToLower(combined)
-
-	reg := regexp.MustCompile(`[^a-z0-9]+`)
-	combined = reg.ReplaceAllString(combined, "")
-
-	return combined
-}
-
-// makeSchemaAnchor creates an anchor for a schema name
-func makeSchemaAnchor(schemaName string) string {
-	anchor := strings.ToLower(schemaName)
-	reg := regexp.MustCompile(`[^a-z0-9]+`)
-	anchor = reg.ReplaceAllString(anchor, "-")
-	return strings.Trim(anchor, "-")
-}
-
 // renderSharedDefinitions renders shared schema definitions section
-func renderSharedDefinitions(builder *strings.Builder, sharedSchemas map[string]schemaUsage, model v3.Document, examples map[string]json.RawMessage) error {
-	if len(sharedSchemas) == 0 {
+func (r *renderer) renderSharedDefinitions(builder *strings.Builder) error {
+	if len(r.sharedSchemas) == 0 {
 		return nil
 	}
 
-	builder.WriteString("## Shared Schema Definitions\n\n")
+	builder.WriteString(r.heading(2) + "Shared Schema Definitions\n\n")
 
 	// Sort schema names for consistent ordering
-	schemaNames := make([]string, 0, len(sharedSchemas))
-	for name := range sharedSchemas {
+	schemaNames := make([]string, 0, len(r.sharedSchemas))
+	for name := range r.sharedSchemas {
 		schemaNames = append(schemaNames, name)
 	}
 	sort.Strings(schemaNames)
 
 	for _, schemaName := range schemaNames {
-		usage := sharedSchemas[schemaName]
+		usage := r.sharedSchemas[schemaName]
 
-		builder.WriteString("### ")
+		builder.WriteString(r.heading(3))
 		builder.WriteString(schemaName)
 		builder.WriteString("\n\n")
 
@@ -330,12 +514,12 @@ func renderSharedDefinitions(builder *strings.Builder, sharedSchemas map[string]
 		}
 
 		// Get schema from model
-		if model.Components != nil && model.Components.Schemas != nil {
-			schemaPair := model.Components.Schemas.GetOrZero(schemaName)
+		if r.model.Components != nil && r.model.Components.Schemas != nil {
+			schemaPair := r.model.Components.Schemas.GetOrZero(schemaName)
 			if schemaPair != nil {
 				schema := schemaPair.Schema()
 				if schema != nil {
-					if err := renderSharedSchemaFields(builder, schema, schemaName); err != nil {
+					if err := r.renderSharedSchemaFields(builder, schema, schemaName); err != nil {
 						return err
 					}
 				}
@@ -347,7 +531,7 @@ func renderSharedDefinitions(builder *strings.Builder, sharedSchemas map[string]
 }
 
 // renderSharedFieldsList renders a list of schema fields in the shared definitions format
-func renderSharedFieldsList(builder *strings.Builder, fields []schemaField, nestedDefs []schemaDefinition, schemaName string) error {
+func (r *renderer) renderSharedFieldsList(builder *strings.Builder, fields []schemaField, nestedDefs []schemaDefinition, schemaName string) error {
 	for _, field := range fields {
 		builder.WriteString("- `")
 		builder.WriteString(field.name)
@@ -386,7 +570,8 @@ func renderSharedFieldsList(builder *strings.Builder, fields []schemaField, nest
 			builder.WriteString(" ")
 			builder.WriteString(field.description)
 		} else if !field.isObject {
-			log.Printf("Warning: Field '%s' in schema '%s' is missing a description", field.name, schemaName)
+			r.warn(WarningMissingFieldDescription, r.fieldPointer(schemaPointer(schemaName), field.name),
+				"field %q in schema %q is missing a description", field.name, schemaName)
 		}
 
 		if len(field.enum) > 0 {
@@ -416,7 +601,7 @@ func renderSharedFieldsList(builder *strings.Builder, fields []schemaField, nest
 }
 
 // renderSharedSchemaFields renders fields for a shared schema, handling oneOf, allOf, and plain properties
-func renderSharedSchemaFields(builder *strings.Builder, schema *base.Schema, schemaName string) error {
+func (r *renderer) renderSharedSchemaFields(builder *strings.Builder, schema *base.Schema, schemaName string) error {
 	const maxDepth = 10
 
 	// Handle oneOf schemas
@@ -426,11 +611,11 @@ func renderSharedSchemaFields(builder *strings.Builder, schema *base.Schema, sch
 		if mergedProps != nil && mergedProps.Len() > 0 {
 			visited := make(map[string]int)
 			visited[schemaName] = 1
-			fields, nestedDefs, err := extractSchemaFieldsFromProperties(schema, visited, maxDepth)
+			fields, nestedDefs, err := r.extractSchemaFieldsFromProperties(schema, visited, maxDepth)
 			if err != nil {
 				return err
 			}
-			if err := renderSharedFieldsList(builder, fields, nestedDefs, schemaName); err != nil {
+			if err := r.renderSharedFieldsList(builder, fields, nestedDefs, schemaName); err != nil {
 				return err
 			}
 		}
@@ -491,12 +676,12 @@ func renderSharedSchemaFields(builder *strings.Builder, schema *base.Schema, sch
 
 			visited := make(map[string]int)
 			visited[schemaName] = 1
-			fields, nestedDefs, err := extractSchemaFieldsFromProperties(variantSchema, visited, maxDepth)
+			fields, nestedDefs, err := r.extractSchemaFieldsFromProperties(variantSchema, visited, maxDepth)
 			if err != nil {
 				return err
 			}
 
-			if err := renderFieldsListInline(builder, fields, nestedDefs, ""); err != nil {
+			if err := r.renderFieldsListInline(builder, fields, nestedDefs, schemaProxyPointer(variantProxy), ""); err != nil {
 				return err
 			}
 		}
@@ -513,19 +698,26 @@ func renderSharedSchemaFields(builder *strings.Builder, schema *base.Schema, sch
 	visited := make(map[string]int)
 	visited[schemaName] = 1
 
-	fields, nestedDefs, err := extractSchemaFieldsFromProperties(schema, visited, maxDepth)
+	fields, nestedDefs, err := r.extractSchemaFieldsFromProperties(schema, visited, maxDepth)
 	if err != nil {
 		return err
 	}
 
-	return renderSharedFieldsList(builder, fields, nestedDefs, schemaName)
+	return r.renderSharedFieldsList(builder, fields, nestedDefs, schemaName)
 }
 
-func generateMarkdown(opts ConvertOptions, endpoints []endpoint, tagGroups map[string][]endpoint, examples map[string]json.RawMessage, sharedSchemas map[string]schemaUsage, model v3.Document) (string, []string, error) {
+func generateMarkdown(opts ConvertOptions, endpoints []endpoint, deprecated []endpoint, tagGroups map[string][]endpoint, examples map[string]json.RawMessage, sharedSchemas map[string]schemaUsage, model v3.Document, rewritten bool) (string, []Warning, error) {
 	var builder strings.Builder
-	var warnings []string
 
-	builder.WriteString("# ")
+	r := &renderer{
+		opts:          opts,
+		examples:      examples,
+		sharedSchemas: sharedSchemas,
+		model:         model,
+		rewritten:     rewritten,
+	}
+
+	builder.WriteString(r.heading(1))
 	builder.WriteString(opts.Title)
 	builder.WriteString("\n\n")
 
@@ -534,115 +726,353 @@ func generateMarkdown(opts ConvertOptions, endpoints []endpoint, tagGroups map[s
 		builder.WriteString("\n\n")
 	}
 
-	if len(endpoints) > 0 {
-		builder.WriteString("## Table of Contents\n\n")
-		builder.WriteString("HTTP Request | Description\n")
-		builder.WriteString("-------------|------------\n")
+	if len(endpoints) > 0 || len(deprecated) > 0 {
+		tags := sortTags(tagGroups)
 
-		for _, e := range endpoints {
-			anchor := makeAnchor(e.method, e.path)
-			builder.WriteString(e.method)
-			builder.WriteString(" [")
-			builder.WriteString(e.path)
-			builder.WriteString("](#")
-			builder.WriteString(anchor)
-			builder.WriteString(") | ")
-			builder.WriteString(e.summary)
-			builder.WriteString("\n")
-		}
-
-		builder.WriteString("\n")
-
-		tags := make([]string, 0, len(tagGroups))
-		for tag := range tagGroups {
-			tags = append(tags, tag)
-		}
-		sort.Strings(tags)
-
-		defaultIndex := -1
-		for i, tag := range tags {
-			if tag == "Default APIs" {
-				defaultIndex = i
-				break
+		var ordered []endpoint
+		if len(tags) > 1 {
+			for _, tag := range tags {
+				ordered = append(ordered, tagGroups[tag]...)
 			}
+		} else {
+			ordered = append(ordered, endpoints...)
 		}
-		if defaultIndex != -1 {
-			tags = append(tags[:defaultIndex], tags[defaultIndex+1:]...)
-			tags = append(tags, "Default APIs")
-		}
+		r.assignAnchors(append(ordered, deprecated...))
+
+		r.renderTableOfContents(&builder, endpoints, deprecated, tags, tagGroups)
 
 		if len(tags) > 1 {
 			for _, tag := range tags {
-				endpoints := tagGroups[tag]
-
-				builder.WriteString("## ")
+				builder.WriteString(r.heading(2))
 				builder.WriteString(tag)
 				builder.WriteString("\n\n")
 
-				for _, e := range endpoints {
-					builder.WriteString("### ")
-					builder.WriteString(e.method)
-					builder.WriteString(" ")
-					builder.WriteString(e.path)
-					builder.WriteString("\n\n")
-
-					if e.description != "" {
-						builder.WriteString(e.description)
-						builder.WriteString("\n\n")
-					} else if e.summary != "" {
-						builder.WriteString(e.summary)
-						builder.WriteString("\n\n")
-					} else {
-						log.Printf("Warning: No description or summary for %s %s", e.method, e.path)
-					}
-
-					renderParameters(&builder, e.operation)
-					if err := renderRequestBody(&builder, e.operation, examples, sharedSchemas); err != nil {
-						return "", nil, err
-					}
-					if err := renderResponses(&builder, e.operation, examples, sharedSchemas); err != nil {
+				for _, e := range tagGroups[tag] {
+					if err := r.renderEndpoint(&builder, e, 3); err != nil {
 						return "", nil, err
 					}
 				}
 			}
 		} else {
 			for _, e := range endpoints {
-				builder.WriteString("## ")
-				builder.WriteString(e.method)
-				builder.WriteString(" ")
-				builder.WriteString(e.path)
-				builder.WriteString("\n\n")
-
-				if e.description != "" {
-					builder.WriteString(e.description)
-					builder.WriteString("\n\n")
-				} else if e.summary != "" {
-					builder.WriteString(e.summary)
-					builder.WriteString("\n\n")
-				} else {
-					log.Printf("Warning: No description or summary for %s %s", e.method, e.path)
-				}
-
-				renderParameters(&builder, e.operation)
-				if err := renderRequestBody(&builder, e.operation, examples, sharedSchemas); err != nil {
+				if err := r.renderEndpoint(&builder, e, 2); err != nil {
 					return "", nil, err
 				}
-				if err := renderResponses(&builder, e.operation, examples, sharedSchemas); err != nil {
+			}
+		}
+
+		if len(deprecated) > 0 {
+			builder.WriteString(r.heading(2) + "Deprecated\n\n")
+
+			for _, e := range deprecated {
+				if err := r.renderEndpoint(&builder, e, 3); err != nil {
 					return "", nil, err
 				}
 			}
 		}
 
 		// Render shared schema definitions section at the bottom
-		if err := renderSharedDefinitions(&builder, sharedSchemas, model, examples); err != nil {
+		if err := r.renderSharedDefinitions(&builder); err != nil {
 			return "", nil, err
 		}
 	}
 
-	return builder.String(), warnings, nil
+	return builder.String(), r.warnings, nil
+}
+
+// sortTags returns tag names in document order: alphabetical with "Default APIs" last
+func sortTags(tagGroups map[string][]endpoint) []string {
+	tags := make([]string, 0, len(tagGroups))
+	for tag := range tagGroups {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	defaultIndex := -1
+	for i, tag := range tags {
+		if tag == defaultTag {
+			defaultIndex = i
+			break
+		}
+	}
+	if defaultIndex != -1 {
+		tags = append(tags[:defaultIndex], tags[defaultIndex+1:]...)
+		tags = append(tags, defaultTag)
+	}
+
+	return tags
+}
+
+// renderEndpoint renders a single operation section with its heading at the given level
+func (r *renderer) renderEndpoint(builder *strings.Builder, e endpoint, level int) error {
+	key := endpointKey(e)
+	r.operation, r.pointer = key, operationPointer(e.method, e.path)
+	defer func() { r.operation, r.pointer = "", "" }()
+
+	if r.explicitAnchors[key] {
+		builder.WriteString("<a id=\"")
+		builder.WriteString(r.anchor(e))
+		builder.WriteString("\"></a>\n\n")
+		// Only the first occurrence of an operation rendered under several tags gets the anchor
+		r.explicitAnchors[key] = false
+	}
+
+	builder.WriteString(r.heading(level))
+	builder.WriteString(endpointHeading(e))
+	builder.WriteString("\n\n")
+
+	if e.operation != nil && e.operation.OperationId != "" {
+		builder.WriteString("Operation ID: `")
+		builder.WriteString(e.operation.OperationId)
+		builder.WriteString("`\n\n")
+	}
+
+	if e.description != "" {
+		builder.WriteString(e.description)
+		builder.WriteString("\n\n")
+	} else if e.summary != "" {
+		builder.WriteString(e.summary)
+		builder.WriteString("\n\n")
+	} else {
+		r.warn(WarningMissingDescription, r.pointer, "%s has no description or summary", key)
+	}
+
+	if e.deprecated {
+		renderDeprecationNotice(builder, e.operation)
+	}
+
+	r.renderParameters(builder, e)
+	located := func(err error) error {
+		return locate(err, r.pointer, func(se *SpecError) { se.Operation = key })
+	}
+	if err := r.renderCodeSamples(builder, e); err != nil {
+		return located(err)
+	}
+	if err := r.renderRequestBody(builder, e.operation); err != nil {
+		return located(err)
+	}
+	return located(r.renderResponses(builder, e.operation))
+}
+
+// renderDeprecationNotice renders a notice for a deprecated operation, including any
+// x-deprecated-* extensions (e.g. x-deprecated-sunset) as additional details
+func renderDeprecationNotice(builder *strings.Builder, op *v3.Operation) {
+	const prefix = "x-deprecated-"
+
+	builder.WriteString("> **Deprecated:** This operation is deprecated and may be removed in a future version.\n")
+
+	if op != nil && op.Extensions != nil {
+		for pair := op.Extensions.First(); pair != nil; pair = pair.Next() {
+			key := pair.Key()
+			node := pair.Value()
+			if !strings.HasPrefix(key, prefix) || node == nil {
+				continue
+			}
+
+			label := strings.ReplaceAll(strings.TrimPrefix(key, prefix), "-", " ")
+			if label == "" {
+				continue
+			}
+
+			// Scalars are printed as written so that dates like 2025-01-01 are not decoded
+			// into a time.Time
+			value := node.Value
+			if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+				var decoded interface{}
+				if err := node.Decode(&decoded); err != nil {
+					continue
+				}
+				value = fmt.Sprintf("%v", decoded)
+			}
+
+			builder.WriteString(">\n> **")
+			first, size := utf8.DecodeRuneInString(label)
+			builder.WriteRune(unicode.ToUpper(first))
+			builder.WriteString(label[size:])
+			builder.WriteString(":** ")
+			builder.WriteString(value)
+			builder.WriteString("\n")
+		}
+	}
+
+	builder.WriteString("\n")
+}
+
+// deepestHeading is the deepest heading level generated before HeadingOffset is applied
+const deepestHeading = 4
+
+// heading returns the markdown prefix for a heading at level, shifted by HeadingOffset
+func (r *renderer) heading(level int) string {
+	return strings.Repeat("#", level+r.opts.HeadingOffset) + " "
+}
+
+// tocGroup is a named collection of tags from the x-tagGroups extension
+type tocGroup struct {
+	name string
+	tags []string
+}
+
+// extractTOCGroups arranges tags into the groups declared by the x-tagGroups extension.
+// Tags not claimed by any group are returned in a trailing group with an empty name.
+func extractTOCGroups(model v3.Document, tags []string) []tocGroup {
+	known := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		known[tag] = true
+	}
+
+	var declared []struct {
+		Name string   `yaml:"name"`
+		Tags []string `yaml:"tags"`
+	}
+	if model.Extensions != nil {
+		if node := model.Extensions.GetOrZero("x-tagGroups"); node != nil {
+			if err := node.Decode(&declared); err != nil {
+				declared = nil
+			}
+		}
+	}
+
+	var groups []tocGroup
+	claimed := make(map[string]bool)
+	for _, d := range declared {
+		group := tocGroup{name: d.Name}
+		for _, tag := range d.Tags {
+			if known[tag] && !claimed[tag] {
+				group.tags = append(group.tags, tag)
+				claimed[tag] = true
+			}
+		}
+		if len(group.tags) > 0 {
+			groups = append(groups, group)
+		}
+	}
+
+	ungrouped := tocGroup{}
+	for _, tag := range tags {
+		if !claimed[tag] {
+			ungrouped.tags = append(ungrouped.tags, tag)
+		}
+	}
+	if len(ungrouped.tags) > 0 {
+		groups = append(groups, ungrouped)
+	}
+
+	return groups
+}
+
+// renderTableOfContents renders the table of contents, nesting operations under their tag
+// (and x-tagGroups) when the document body is grouped by tag
+func (r *renderer) renderTableOfContents(builder *strings.Builder, endpoints []endpoint, deprecated []endpoint, tags []string, tagGroups map[string][]endpoint) {
+	builder.WriteString(r.heading(2) + "Table of Contents\n\n")
+
+	if len(tags) <= 1 {
+		if len(endpoints) > 0 {
+			r.renderTOCEndpoints(builder, endpoints, "")
+		}
+	} else {
+		for _, group := range extractTOCGroups(r.model, tags) {
+			indent := ""
+			if group.name != "" {
+				if r.opts.TOCStyle == TOCList {
+					builder.WriteString("- ")
+					builder.WriteString(group.name)
+					builder.WriteString("\n")
+					indent = "  "
+				} else {
+					builder.WriteString("**")
+					builder.WriteString(group.name)
+					builder.WriteString("**\n\n")
+				}
+			}
+
+			for _, tag := range group.tags {
+				if r.opts.TOCStyle == TOCList {
+					builder.WriteString(indent)
+					builder.WriteString("- [")
+					builder.WriteString(tag)
+					builder.WriteString("](#")
+					builder.WriteString(makeTagAnchor(tag))
+					builder.WriteString(")\n")
+					r.renderTOCEndpoints(builder, tagGroups[tag], indent+"  ")
+				} else {
+					builder.WriteString("[")
+					builder.WriteString(tag)
+					builder.WriteString("](#")
+					builder.WriteString(makeTagAnchor(tag))
+					builder.WriteString(")\n\n")
+					r.renderTOCEndpoints(builder, tagGroups[tag], "")
+				}
+			}
+		}
+	}
+
+	if len(deprecated) > 0 {
+		if r.opts.TOCStyle == TOCList {
+			builder.WriteString("- [Deprecated](#deprecated)\n")
+			r.renderTOCEndpoints(builder, deprecated, "  ")
+		} else {
+			builder.WriteString("[Deprecated](#deprecated)\n\n")
+			r.renderTOCEndpoints(builder, deprecated, "")
+		}
+	}
+
+	if r.opts.TOCStyle == TOCList {
+		if len(r.sharedSchemas) > 0 {
+			builder.WriteString("- [Shared Schema Definitions](#shared-schema-definitions)\n")
+		}
+		builder.WriteString("\n")
+	} else if len(r.sharedSchemas) > 0 {
+		builder.WriteString("[Shared Schema Definitions](#shared-schema-definitions)\n\n")
+	}
 }
 
-func renderParameters(builder *strings.Builder, op *v3.Operation) {
+// renderTOCEndpoints renders the operation entries of a table of contents, either as a
+// table or as bullet list items prefixed with indent
+func (r *renderer) renderTOCEndpoints(builder *strings.Builder, endpoints []endpoint, indent string) {
+	if r.opts.TOCStyle == TOCList {
+		for _, e := range endpoints {
+			builder.WriteString(indent)
+			builder.WriteString("- ")
+			builder.WriteString(e.method)
+			builder.WriteString(" [")
+			builder.WriteString(e.path)
+			builder.WriteString("](#")
+			builder.WriteString(r.anchor(e))
+			builder.WriteString(")")
+			if e.summary != "" {
+				builder.WriteString(" - ")
+				builder.WriteString(e.summary)
+			}
+			if e.deprecated {
+				builder.WriteString(" *(deprecated)*")
+			}
+			builder.WriteString("\n")
+		}
+		return
+	}
+
+	builder.WriteString("HTTP Request | Description\n")
+	builder.WriteString("-------------|------------\n")
+
+	for _, e := range endpoints {
+		builder.WriteString(e.method)
+		builder.WriteString(" [")
+		builder.WriteString(e.path)
+		builder.WriteString("](#")
+		builder.WriteString(r.anchor(e))
+		builder.WriteString(") | ")
+		builder.WriteString(e.summary)
+		if e.deprecated {
+			builder.WriteString(" *(deprecated)*")
+		}
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString("\n")
+}
+
+func (r *renderer) renderParameters(builder *strings.Builder, e endpoint) {
+	op := e.operation
 	if op == nil || op.Parameters == nil {
 		return
 	}
@@ -652,7 +1082,7 @@ func renderParameters(builder *strings.Builder, op *v3.Operation) {
 	headerParams := []v3.Parameter{}
 
 	for _, param := range op.Parameters {
-		if param == nil {
+		if param == nil || isExcludedAudience(r.opts, param.Extensions) {
 			continue
 		}
 		switch param.In {
@@ -665,18 +1095,29 @@ func renderParameters(builder *strings.Builder, op *v3.Operation) {
 		}
 	}
 
-	renderPathParametersFieldDef(builder, pathParams)
-	renderQueryParametersFieldDef(builder, queryParams)
-	renderHeaders(builder, headerParams)
+	r.renderPathParametersFieldDef(builder, pathParams)
+	r.renderQueryParametersFieldDef(builder, queryParams)
+	if len(pathParams) > 0 || len(queryParams) > 0 {
+		builder.WriteString("Example URL: `" + r.sampleURL(e) + "`\n\n")
+	}
+	r.renderHeaders(builder, headerParams)
+}
+
+// parameterExampleText returns the inline example of a parameter, empty when it has none
+func parameterExampleText(param *v3.Parameter) string {
+	if example, ok := parameterExample(param); ok {
+		return "Example: `" + example + "`"
+	}
+	return ""
 }
 
 // renderPathParametersFieldDef renders path parameters in field definitions format
-func renderPathParametersFieldDef(builder *strings.Builder, params []v3.Parameter) {
+func (r *renderer) renderPathParametersFieldDef(builder *strings.Builder, params []v3.Parameter) {
 	if len(params) == 0 {
 		return
 	}
 
-	builder.WriteString("#### Path Parameters\n\n")
+	builder.WriteString(r.heading(4) + "Path Parameters\n\n")
 
 	for _, param := range params {
 		if param.Schema != nil && param.Schema.Schema() != nil {
@@ -720,6 +1161,11 @@ func renderPathParametersFieldDef(builder *strings.Builder, params []v3.Paramete
 				}
 			}
 
+			if example := parameterExampleText(&param); example != "" {
+				builder.WriteString(" ")
+				builder.WriteString(example)
+			}
+
 			builder.WriteString("\n")
 		}
 
@@ -728,12 +1174,12 @@ func renderPathParametersFieldDef(builder *strings.Builder, params []v3.Paramete
 }
 
 // renderQueryParametersFieldDef renders query parameters in field definitions format
-func renderQueryParametersFieldDef(builder *strings.Builder, params []v3.Parameter) {
+func (r *renderer) renderQueryParametersFieldDef(builder *strings.Builder, params []v3.Parameter) {
 	if len(params) == 0 {
 		return
 	}
 
-	builder.WriteString("#### Query Parameters\n\n")
+	builder.WriteString(r.heading(4) + "Query Parameters\n\n")
 
 	for _, param := range params {
 		if param.Schema != nil && param.Schema.Schema() != nil {
@@ -777,6 +1223,11 @@ func renderQueryParametersFieldDef(builder *strings.Builder, params []v3.Paramet
 				}
 			}
 
+			if example := parameterExampleText(&param); example != "" {
+				builder.WriteString(" ")
+				builder.WriteString(example)
+			}
+
 			builder.WriteString("\n")
 		}
 
@@ -785,12 +1236,12 @@ func renderQueryParametersFieldDef(builder *strings.Builder, params []v3.Paramet
 }
 
 // renderHeaders renders header parameters in table format
-func renderHeaders(builder *strings.Builder, params []v3.Parameter) {
+func (r *renderer) renderHeaders(builder *strings.Builder, params []v3.Parameter) {
 	if len(params) == 0 {
 		return
 	}
 
-	builder.WriteString("#### Headers\n\n")
+	builder.WriteString(r.heading(4) + "Headers\n\n")
 	builder.WriteString("Name | Description | Required | Type\n")
 	builder.WriteString("-----|-------------|----------|-----\n")
 
@@ -798,9 +1249,14 @@ func renderHeaders(builder *strings.Builder, params []v3.Parameter) {
 		builder.WriteString(param.Name)
 		builder.WriteString(" | ")
 
-		if param.Description != "" {
-			builder.WriteString(param.Description)
+		description := strings.TrimSpace(param.Description)
+		if example := parameterExampleText(&param); example != "" {
+			if description != "" {
+				description += " "
+			}
+			description += example
 		}
+		builder.WriteString(escapeTableCell(description))
 		builder.WriteString(" | ")
 
 		if param.Required != nil && *param.Required {
@@ -822,8 +1278,16 @@ func renderHeaders(builder *strings.Builder, params []v3.Parameter) {
 	builder.WriteString("\n")
 }
 
+// tableCellReplacer escapes pipes and replaces line breaks, which would end a markdown table row
+var tableCellReplacer = strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ", "\r", " ")
+
+// escapeTableCell returns s escaped to stay within a single markdown table cell
+func escapeTableCell(s string) string {
+	return tableCellReplacer.Replace(s)
+}
+
 // identifySharedResponseSchemas finds schemas used in multiple 2xx responses within the same endpoint
-func identifySharedResponseSchemas(op *v3.Operation) map[string][]string {
+func (r *renderer) identifySharedResponseSchemas(op *v3.Operation) map[string][]string {
 	if op == nil || op.Responses == nil || op.Responses.Codes == nil {
 		return nil
 	}
@@ -840,7 +1304,7 @@ func identifySharedResponseSchemas(op *v3.Operation) map[string][]string {
 		}
 
 		resp := pair.Value()
-		if resp == nil || resp.Content == nil {
+		if resp == nil || resp.Content == nil || isExcludedAudience(r.opts, resp.Extensions) {
 			continue
 		}
 
@@ -876,21 +1340,27 @@ func identifySharedResponseSchemas(op *v3.Operation) map[string][]string {
 	return sharedSchemas
 }
 
-func renderResponses(builder *strings.Builder, op *v3.Operation, examples map[string]json.RawMessage, sharedSchemas map[string]schemaUsage) error {
+func (r *renderer) renderResponses(builder *strings.Builder, op *v3.Operation) error {
 	if op == nil || op.Responses == nil || op.Responses.Codes == nil {
 		return nil
 	}
 
-	builder.WriteString("### Responses\n\n")
-
 	codes := []string{}
 	for pair := op.Responses.Codes.First(); pair != nil; pair = pair.Next() {
+		if pair.Value() != nil && isExcludedAudience(r.opts, pair.Value().Extensions) {
+			continue
+		}
 		codes = append(codes, pair.Key())
 	}
+	if len(codes) == 0 {
+		return nil
+	}
 	sort.Strings(codes)
 
+	builder.WriteString(r.heading(3) + "Responses\n\n")
+
 	// Identify shared schemas across 2xx responses within this endpoint
-	responseSharedSchemas := identifySharedResponseSchemas(op)
+	responseSharedSchemas := r.identifySharedResponseSchemas(op)
 
 	// Track which schemas we've already rendered field definitions for
 	renderedSchemas := make(map[string]bool)
@@ -899,7 +1369,7 @@ func renderResponses(builder *strings.Builder, op *v3.Operation, examples map[st
 	for _, code := range codes {
 		resp := op.Responses.Codes.GetOrZero(code)
 
-		builder.WriteString("#### ")
+		builder.WriteString(r.heading(4))
 		builder.WriteString(code)
 		builder.WriteString(" Response\n\n")
 
@@ -908,16 +1378,11 @@ func renderResponses(builder *strings.Builder, op *v3.Operation, examples map[st
 			builder.WriteString("\n\n")
 		}
 
-		exampleJSON, err := extractResponseExample(resp, examples)
+		examples, err := r.extractResponseExample(resp)
 		if err != nil {
-			return err
-		}
-
-		if exampleJSON != "" {
-			builder.WriteString("```json\n")
-			builder.WriteString(exampleJSON)
-			builder.WriteString("\n```\n\n")
+			return locate(err, "/responses/"+escapePointer(code), func(e *SpecError) { e.StatusCode = code })
 		}
+		renderExamples(builder, examples)
 
 		// Only render field definitions for 2xx responses
 		if strings.HasPrefix(code, "2") {
@@ -941,7 +1406,7 @@ func renderResponses(builder *strings.Builder, op *v3.Operation, examples map[st
 					// Check if this schema is shared with other 2xx responses
 					if responseCodes, isShared := responseSharedSchemas[schemaName]; isShared && !renderedSchemas[schemaName] {
 						// Render field definitions once with note about which responses it applies to
-						builder.WriteString("#### Field Definitions (applies to ")
+						builder.WriteString(r.heading(4) + "Field Definitions (applies to ")
 						for i, rc := range responseCodes {
 							if i > 0 {
 								builder.WriteString(", ")
@@ -950,15 +1415,15 @@ func renderResponses(builder *strings.Builder, op *v3.Operation, examples map[st
 						}
 						builder.WriteString(" responses)\n\n")
 
-						if err := renderFieldDefinitionsContent(builder, schemaProxy, examples, sharedSchemas); err != nil {
+						if err := r.renderFieldDefinitionsContent(builder, schemaProxy); err != nil {
 							return err
 						}
 
 						renderedSchemas[schemaName] = true
 					} else if !isShared {
 						// Not shared, render field definitions normally
-						builder.WriteString("#### Field Definitions\n\n")
-						if err := renderFieldDefinitionsContent(builder, schemaProxy, examples, sharedSchemas); err != nil {
+						builder.WriteString(r.heading(4) + "Field Definitions\n\n")
+						if err := r.renderFieldDefinitionsContent(builder, schemaProxy); err != nil {
 							return err
 						}
 					}
@@ -970,7 +1435,7 @@ func renderResponses(builder *strings.Builder, op *v3.Operation, examples map[st
 	return nil
 }
 
-func collectDebugInfo(model v3.Document, endpoints []endpoint, tagGroups map[string][]endpoint, sharedSchemas map[string]schemaUsage) *DebugInfo {
+func collectDebugInfo(model v3.Document, endpoints []endpoint, tagGroups map[string][]endpoint, sharedSchemas map[string]schemaUsage, opts ConvertOptions) *DebugInfo {
 	debug := &DebugInfo{
 		ParameterCounts:   make(map[string]int),
 		ResponseCounts:    make(map[string]int),
@@ -1015,6 +1480,7 @@ func collectDebugInfo(model v3.Document, endpoints []endpoint, tagGroups map[str
 	}
 
 	debug.SharedSchemaCount = len(sharedSchemas)
+	debug.Stats = collectStats(model, endpoints, opts)
 
 	return debug
 }
@@ -1038,12 +1504,11 @@ func generateComponentExamples(openapi []byte) (map[string]json.RawMessage, erro
 
 // extractSchemaName extracts schema name from $ref (e.g., "#/components/schemas/Pet" -> "Pet")
 func extractSchemaName(ref string) (string, error) {
-	const prefix = "#/components/schemas/"
-	if !strings.HasPrefix(ref, prefix) {
+	if !strings.HasPrefix(ref, schemaRefPrefix) {
 		return "", fmt.Errorf("invalid schema reference format: %s", ref)
 	}
 
-	name := strings.TrimPrefix(ref, prefix)
+	name := strings.TrimPrefix(ref, schemaRefPrefix)
 	if name == "" {
 		return "", fmt.Errorf("empty schema name in reference: %s", ref)
 	}
@@ -1102,22 +1567,22 @@ func resolveDiscriminatorValue(disc *base.Discriminator, variantProxy *base.Sche
 }
 
 // getExampleFromSchema generates example from schema using pre-generated examples
-func getExampleFromSchema(schemaProxy *base.SchemaProxy, examples map[string]json.RawMessage) (string, error) {
+func (r *renderer) getExampleFromSchema(schemaProxy *base.SchemaProxy) (string, error) {
 	if schemaProxy == nil {
 		return "", nil
 	}
 
 	if !schemaProxy.IsReference() {
-		return "", fmt.Errorf("inline schemas not supported in responses, use $ref")
+		return "", locate(r.specError(schemaProxy.GetValueNode(), fmt.Errorf("inline schemas not supported, use $ref")), "/schema", nil)
 	}
 
 	ref := schemaProxy.GetReference()
 	schemaName, err := extractSchemaName(ref)
 	if err != nil {
-		return "", err
+		return "", locate(r.specError(schemaProxy.GetValueNode(), err), "/schema", nil)
 	}
 
-	exampleJSON, found := examples[schemaName]
+	exampleJSON, found := r.examples[schemaName]
 	if !found {
 		return "", nil
 	}
@@ -1126,6 +1591,7 @@ func getExampleFromSchema(schemaProxy *base.SchemaProxy, examples map[string]jso
 	if err := json.Unmarshal(exampleJSON, &value); err != nil {
 		return "", nil
 	}
+	r.pruneExample(value, schemaProxy)
 
 	formatted, err := json.MarshalIndent(value, "", "   ")
 	if err != nil {
@@ -1135,39 +1601,71 @@ func getExampleFromSchema(schemaProxy *base.SchemaProxy, examples map[string]jso
 	return string(formatted), nil
 }
 
-// getExampleFromMediaType extracts explicit example from MediaType
-func getExampleFromMediaType(mt *v3.MediaType) string {
+// jsonExample is a JSON example of a request body or response. Named examples carry the title
+// and description they are rendered with.
+type jsonExample struct {
+	title       string
+	description string
+	json        string
+}
+
+// getExamplesFromMediaType extracts the explicit example from MediaType, or every named example
+// up to MaxExamples
+func (r *renderer) getExamplesFromMediaType(mt *v3.MediaType) []jsonExample {
 	if mt.Example != nil {
-		var value interface{}
-		if err := mt.Example.Decode(&value); err == nil {
-			if formatted, err := json.MarshalIndent(value, "", "   "); err == nil {
-				return string(formatted)
-			}
+		if formatted := r.formatExample(mt.Example, mt.Schema); formatted != "" {
+			return []jsonExample{{json: formatted}}
 		}
 	}
 
-	if mt.Examples != nil && mt.Examples.Len() > 0 {
-		pair := mt.Examples.First()
-		if pair != nil {
-			example := pair.Value()
-			if example != nil && example.Value != nil {
-				var value interface{}
-				if err := example.Value.Decode(&value); err == nil {
-					if formatted, err := json.MarshalIndent(value, "", "   "); err == nil {
-						return string(formatted)
-					}
-				}
-			}
+	if mt.Examples == nil {
+		return nil
+	}
+
+	var examples []jsonExample
+	for pair := mt.Examples.First(); pair != nil; pair = pair.Next() {
+		if r.opts.MaxExamples > 0 && len(examples) == r.opts.MaxExamples {
+			break
 		}
+
+		example := pair.Value()
+		if example == nil || example.Value == nil {
+			continue
+		}
+		formatted := r.formatExample(example.Value, mt.Schema)
+		if formatted == "" {
+			continue
+		}
+
+		title := example.Summary
+		if title == "" {
+			title = pair.Key()
+		}
+		examples = append(examples, jsonExample{title: title, description: example.Description, json: formatted})
 	}
 
-	return ""
+	return examples
 }
 
-// extractResponseExample extracts or generates a JSON example for a response
-func extractResponseExample(resp *v3.Response, examples map[string]json.RawMessage) (string, error) {
+// formatExample decodes an example value and formats it as indented JSON, or returns "" when
+// it cannot be
+func (r *renderer) formatExample(node *yaml.Node, schemaProxy *base.SchemaProxy) string {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return ""
+	}
+	r.pruneExample(value, schemaProxy)
+	formatted, err := json.MarshalIndent(value, "", "   ")
+	if err != nil {
+		return ""
+	}
+	return string(formatted)
+}
+
+// extractResponseExample extracts or generates the JSON examples for a response
+func (r *renderer) extractResponseExample(resp *v3.Response) ([]jsonExample, error) {
 	if resp.Content == nil || resp.Content.Len() == 0 {
-		return "", nil
+		return nil, nil
 	}
 
 	for pair := resp.Content.First(); pair != nil; pair = pair.Next() {
@@ -1181,28 +1679,28 @@ func extractResponseExample(resp *v3.Response, examples map[string]json.RawMessa
 			continue
 		}
 
-		if explicit := getExampleFromMediaType(mt); explicit != "" {
+		if explicit := r.getExamplesFromMediaType(mt); len(explicit) > 0 {
 			return explicit, nil
 		}
 
 		if mt.Schema != nil {
-			generated, err := getExampleFromSchema(mt.Schema, examples)
+			generated, err := r.getExampleFromSchema(mt.Schema)
 			if err != nil {
-				return "", err
+				return nil, locate(err, "/content/"+escapePointer(mediaType), func(e *SpecError) { e.MediaType = mediaType })
 			}
 			if generated != "" {
-				return generated, nil
+				return []jsonExample{{json: generated}}, nil
 			}
 		}
 	}
 
-	return "", nil
+	return nil, nil
 }
 
-// extractRequestExample extracts or generates JSON example for request body
-func extractRequestExample(op *v3.Operation, examples map[string]json.RawMessage) (string, error) {
-	if op.RequestBody == nil || op.RequestBody.Content == nil {
-		return "", nil
+// extractRequestExample extracts or generates the JSON examples for request body
+func (r *renderer) extractRequestExample(op *v3.Operation) ([]jsonExample, error) {
+	if op.RequestBody == nil || op.RequestBody.Content == nil || isExcludedAudience(r.opts, op.RequestBody.Extensions) {
+		return nil, nil
 	}
 
 	for pair := op.RequestBody.Content.First(); pair != nil; pair = pair.Next() {
@@ -1216,22 +1714,38 @@ func extractRequestExample(op *v3.Operation, examples map[string]json.RawMessage
 			continue
 		}
 
-		if explicit := getExampleFromMediaType(mt); explicit != "" {
+		if explicit := r.getExamplesFromMediaType(mt); len(explicit) > 0 {
 			return explicit, nil
 		}
 
 		if mt.Schema != nil {
-			generated, err := getExampleFromSchema(mt.Schema, examples)
+			generated, err := r.getExampleFromSchema(mt.Schema)
 			if err != nil {
-				return "", err
+				return nil, locate(err, "/requestBody/content/"+escapePointer(mediaType), func(e *SpecError) { e.MediaType = mediaType })
 			}
 			if generated != "" {
-				return generated, nil
+				return []jsonExample{{json: generated}}, nil
 			}
 		}
 	}
 
-	return "", nil
+	return nil, nil
+}
+
+// renderExamples renders JSON examples as code blocks, each named example under its title and
+// description
+func renderExamples(builder *strings.Builder, examples []jsonExample) {
+	for _, example := range examples {
+		if example.title != "" {
+			builder.WriteString("**" + example.title + "**\n\n")
+		}
+		if example.description != "" {
+			builder.WriteString(example.description + "\n\n")
+		}
+		builder.WriteString("```json\n")
+		builder.WriteString(example.json)
+		builder.WriteString("\n```\n\n")
+	}
 }
 
 // mergeAllOfProperties merges properties from allOf members with the schema's own properties.
@@ -1272,7 +1786,8 @@ func mergeAllOfProperties(schema *base.Schema) (*orderedmap.Map[string, *base.Sc
 }
 
 // renderFieldsList renders a list of schema fields and their nested definitions
-func renderFieldsList(builder *strings.Builder, fields []schemaField, nestedDefs []schemaDefinition) error {
+// pointer locates the component schema owning the fields, empty for inline schemas.
+func (r *renderer) renderFieldsList(builder *strings.Builder, fields []schemaField, nestedDefs []schemaDefinition, pointer string) error {
 	for _, field := range fields {
 		builder.WriteString("- `")
 		builder.WriteString(field.name)
@@ -1311,7 +1826,7 @@ func renderFieldsList(builder *strings.Builder, fields []schemaField, nestedDefs
 			builder.WriteString(" ")
 			builder.WriteString(field.description)
 		} else if !field.isObject {
-			log.Printf("Warning: Field '%s' is missing a description", field.name)
+			r.warn(WarningMissingFieldDescription, r.fieldPointer(pointer, field.name), "field %q is missing a description", field.name)
 		}
 
 		if len(field.enum) > 0 {
@@ -1343,7 +1858,7 @@ func renderFieldsList(builder *strings.Builder, fields []schemaField, nestedDefs
 // renderFieldsListInline renders fields with nested objects indented inline rather than as
 // separate peer-level sections. Used for oneOf variant rendering where the JSON structure
 // should be reflected in the documentation hierarchy.
-func renderFieldsListInline(builder *strings.Builder, fields []schemaField, nestedDefs []schemaDefinition, indent string) error {
+func (r *renderer) renderFieldsListInline(builder *strings.Builder, fields []schemaField, nestedDefs []schemaDefinition, pointer, indent string) error {
 	// Build lookup map from nested definitions
 	nestedMap := make(map[string]schemaDefinition, len(nestedDefs))
 	for _, def := range nestedDefs {
@@ -1380,7 +1895,7 @@ func renderFieldsListInline(builder *strings.Builder, fields []schemaField, nest
 			builder.WriteString(" ")
 			builder.WriteString(field.description)
 		} else if !field.isObject {
-			log.Printf("Warning: Field '%s' is missing a description", field.name)
+			r.warn(WarningMissingFieldDescription, r.fieldPointer(pointer, field.name), "field %q is missing a description", field.name)
 		}
 
 		if len(field.enum) > 0 {
@@ -1400,7 +1915,7 @@ func renderFieldsListInline(builder *strings.Builder, fields []schemaField, nest
 		// Inline nested object fields
 		if field.isObject && field.nestedSchemaRef != "" {
 			if nestedDef, ok := nestedMap[field.nestedSchemaRef]; ok {
-				if err := renderFieldsListInline(builder, nestedDef.fields, nestedDefs, indent+"  "); err != nil {
+				if err := r.renderFieldsListInline(builder, nestedDef.fields, nestedDefs, schemaPointer(nestedDef.name), indent+"  "); err != nil {
 					return err
 				}
 			}
@@ -1412,7 +1927,7 @@ func renderFieldsListInline(builder *strings.Builder, fields []schemaField, nest
 }
 
 // renderFieldDefinitionsContent renders the content of field definitions (without the header)
-func renderFieldDefinitionsContent(builder *strings.Builder, schemaProxy *base.SchemaProxy, examples map[string]json.RawMessage, sharedSchemas map[string]schemaUsage) error {
+func (r *renderer) renderFieldDefinitionsContent(builder *strings.Builder, schemaProxy *base.SchemaProxy) error {
 	if schemaProxy == nil {
 		return nil
 	}
@@ -1422,7 +1937,7 @@ func renderFieldDefinitionsContent(builder *strings.Builder, schemaProxy *base.S
 		ref := schemaProxy.GetReference()
 		schemaName, err := extractSchemaName(ref)
 		if err == nil {
-			if _, isShared := sharedSchemas[schemaName]; isShared {
+			if _, isShared := r.sharedSchemas[schemaName]; isShared {
 				// Render reference to shared schema instead of full documentation
 				anchor := makeSchemaAnchor(schemaName)
 				builder.WriteString("See [")
@@ -1445,12 +1960,12 @@ func renderFieldDefinitionsContent(builder *strings.Builder, schemaProxy *base.S
 		// Render sibling properties before oneOf variants
 		hasSiblingProps := schema.Properties != nil && schema.Properties.Len() > 0
 		if hasSiblingProps {
-			fields, nestedDefs, err := extractSchemaFields(schemaProxy, examples, make(map[string]int), 10)
+			fields, nestedDefs, err := r.extractSchemaFields(schemaProxy, make(map[string]int), 10)
 			if err != nil {
 				return err
 			}
 			if len(fields) > 0 {
-				if err := renderFieldsList(builder, fields, nestedDefs); err != nil {
+				if err := r.renderFieldsList(builder, fields, nestedDefs, schemaProxyPointer(schemaProxy)); err != nil {
 					return err
 				}
 			}
@@ -1502,11 +2017,11 @@ func renderFieldDefinitionsContent(builder *strings.Builder, schemaProxy *base.S
 					builder.WriteString("**\n")
 				}
 			}
-			fields, nestedDefs, err := extractSchemaFields(variantProxy, examples, make(map[string]int), 10)
+			fields, nestedDefs, err := r.extractSchemaFields(variantProxy, make(map[string]int), 10)
 			if err != nil {
 				return err
 			}
-			if err := renderFieldsListInline(builder, fields, nestedDefs, ""); err != nil {
+			if err := r.renderFieldsListInline(builder, fields, nestedDefs, schemaProxyPointer(variantProxy), ""); err != nil {
 				return err
 			}
 		}
@@ -1519,16 +2034,16 @@ func renderFieldDefinitionsContent(builder *strings.Builder, schemaProxy *base.S
 		return nil
 	}
 
-	fields, nestedDefs, err := extractSchemaFields(schemaProxy, examples, make(map[string]int), 10)
+	fields, nestedDefs, err := r.extractSchemaFields(schemaProxy, make(map[string]int), 10)
 	if err != nil {
 		return err
 	}
 
-	return renderFieldsList(builder, fields, nestedDefs)
+	return r.renderFieldsList(builder, fields, nestedDefs, schemaProxyPointer(schemaProxy))
 }
 
 // renderFieldDefinitions renders field definitions section for a schema
-func renderFieldDefinitions(builder *strings.Builder, schemaProxy *base.SchemaProxy, examples map[string]json.RawMessage, sharedSchemas map[string]schemaUsage) error {
+func (r *renderer) renderFieldDefinitions(builder *strings.Builder, schemaProxy *base.SchemaProxy) error {
 	if schemaProxy == nil {
 		return nil
 	}
@@ -1544,17 +2059,17 @@ func renderFieldDefinitions(builder *strings.Builder, schemaProxy *base.SchemaPr
 
 	// Handle oneOf schemas (discriminated unions)
 	if len(schema.OneOf) > 0 {
-		builder.WriteString("#### Field Definitions\n\n")
+		builder.WriteString(r.heading(4) + "Field Definitions\n\n")
 
 		// Render sibling properties before oneOf variants
 		hasSiblingProps := schema.Properties != nil && schema.Properties.Len() > 0
 		if hasSiblingProps {
-			fields, nestedDefs, err := extractSchemaFields(schemaProxy, examples, make(map[string]int), 10)
+			fields, nestedDefs, err := r.extractSchemaFields(schemaProxy, make(map[string]int), 10)
 			if err != nil {
 				return err
 			}
 			if len(fields) > 0 {
-				if err := renderFieldsList(builder, fields, nestedDefs); err != nil {
+				if err := r.renderFieldsList(builder, fields, nestedDefs, schemaProxyPointer(schemaProxy)); err != nil {
 					return err
 				}
 			}
@@ -1606,11 +2121,11 @@ func renderFieldDefinitions(builder *strings.Builder, schemaProxy *base.SchemaPr
 					builder.WriteString("**\n")
 				}
 			}
-			fields, nestedDefs, err := extractSchemaFields(variantProxy, examples, make(map[string]int), 10)
+			fields, nestedDefs, err := r.extractSchemaFields(variantProxy, make(map[string]int), 10)
 			if err != nil {
 				return err
 			}
-			if err := renderFieldsListInline(builder, fields, nestedDefs, ""); err != nil {
+			if err := r.renderFieldsListInline(builder, fields, nestedDefs, schemaProxyPointer(variantProxy), ""); err != nil {
 				return err
 			}
 		}
@@ -1623,18 +2138,18 @@ func renderFieldDefinitions(builder *strings.Builder, schemaProxy *base.SchemaPr
 		return nil
 	}
 
-	builder.WriteString("#### Field Definitions\n\n")
+	builder.WriteString(r.heading(4) + "Field Definitions\n\n")
 
-	return renderFieldDefinitionsContent(builder, schemaProxy, examples, sharedSchemas)
+	return r.renderFieldDefinitionsContent(builder, schemaProxy)
 }
 
 // renderRequestBody renders request section with JSON and field definitions
-func renderRequestBody(builder *strings.Builder, op *v3.Operation, examples map[string]json.RawMessage, sharedSchemas map[string]schemaUsage) error {
-	if op == nil || op.RequestBody == nil {
+func (r *renderer) renderRequestBody(builder *strings.Builder, op *v3.Operation) error {
+	if op == nil || op.RequestBody == nil || isExcludedAudience(r.opts, op.RequestBody.Extensions) {
 		return nil
 	}
 
-	exampleJSON, err := extractRequestExample(op, examples)
+	examples, err := r.extractRequestExample(op)
 	if err != nil {
 		return err
 	}
@@ -1653,19 +2168,27 @@ func renderRequestBody(builder *strings.Builder, op *v3.Operation, examples map[
 		}
 	}
 
+	// Form bodies (e.g. from Swagger 2.0 formData parameters) are documented field by field
+	formType, formSchema := "", (*base.Schema)(nil)
+	if !hasSchema {
+		formType, formSchema = formRequestSchema(op.RequestBody)
+	}
+
 	// Nothing to render if there is no example and no schema
-	if exampleJSON == "" && !hasSchema {
+	if len(examples) == 0 && !hasSchema && formSchema == nil {
 		return nil
 	}
 
-	builder.WriteString("### Request\n\n")
+	builder.WriteString(r.heading(3) + "Request\n\n")
 
-	if exampleJSON != "" {
-		builder.WriteString("```json\n")
-		builder.WriteString(exampleJSON)
-		builder.WriteString("\n```\n\n")
+	if formSchema != nil {
+		if err := r.renderFormParameters(builder, formType, formSchema); err != nil {
+			return err
+		}
 	}
 
+	renderExamples(builder, examples)
+
 	if op.RequestBody.Content != nil {
 		for pair := op.RequestBody.Content.First(); pair != nil; pair = pair.Next() {
 			mediaType := pair.Key()
@@ -1675,7 +2198,7 @@ func renderRequestBody(builder *strings.Builder, op *v3.Operation, examples map[
 
 			mt := pair.Value()
 			if mt != nil && mt.Schema != nil {
-				if err := renderFieldDefinitions(builder, mt.Schema, examples, sharedSchemas); err != nil {
+				if err := r.renderFieldDefinitions(builder, mt.Schema); err != nil {
 					return err
 				}
 			}
@@ -1685,8 +2208,64 @@ func renderRequestBody(builder *strings.Builder, op *v3.Operation, examples map[
 	return nil
 }
 
+// formRequestSchema returns the media type and schema of a form request body
+func formRequestSchema(body *v3.RequestBody) (string, *base.Schema) {
+	if body.Content == nil {
+		return "", nil
+	}
+
+	for pair := body.Content.First(); pair != nil; pair = pair.Next() {
+		if pair.Key() != formURLEncoded && pair.Key() != formMultipart {
+			continue
+		}
+		if mt := pair.Value(); mt != nil && mt.Schema != nil && mt.Schema.Schema() != nil {
+			return pair.Key(), mt.Schema.Schema()
+		}
+	}
+
+	return "", nil
+}
+
+// renderFormParameters renders the fields of a form request body in field definitions format
+func (r *renderer) renderFormParameters(builder *strings.Builder, mediaType string, schema *base.Schema) error {
+	if schema.Properties == nil || schema.Properties.Len() == 0 {
+		return nil
+	}
+
+	var fields []schemaField
+	for pair := schema.Properties.First(); pair != nil; pair = pair.Next() {
+		prop := pair.Value().Schema()
+		if prop == nil || r.isHiddenSchema(prop) {
+			continue
+		}
+
+		field := schemaField{
+			name:        pair.Key(),
+			required:    slices.Contains(schema.Required, pair.Key()),
+			description: prop.Description,
+		}
+		if len(prop.Type) > 0 {
+			field.typeStr = prop.Type[0]
+		}
+		if prop.Format == "binary" {
+			field.typeStr = "file"
+		}
+		for _, enumVal := range prop.Enum {
+			field.enum = append(field.enum, enumVal.Value)
+		}
+		fields = append(fields, field)
+	}
+
+	builder.WriteString(r.heading(4) + "Form Parameters\n\n")
+	builder.WriteString("Content type: `" + mediaType + "`\n\n")
+
+	// Form schemas are inline, so fields are located within the request body
+	pointer := r.pointer + "/requestBody/content/" + escapePointer(mediaType) + "/schema"
+	return r.renderFieldsList(builder, fields, nil, pointer)
+}
+
 // extractSchemaFieldsFromProperties extracts field information directly from schema properties
-func extractSchemaFieldsFromProperties(schema *base.Schema, visited map[string]int, maxDepth int) ([]schemaField, []schemaDefinition, error) {
+func (r *renderer) extractSchemaFieldsFromProperties(schema *base.Schema, visited map[string]int, maxDepth int) ([]schemaField, []schemaDefinition, error) {
 	if schema == nil {
 		return nil, nil, nil
 	}
@@ -1724,6 +2303,9 @@ func extractSchemaFieldsFromProperties(schema *base.Schema, visited map[string]i
 		}
 
 		prop := propSchema.Schema()
+		if r.isHiddenSchema(prop) {
+			continue
+		}
 
 		field := schemaField{
 			name:        fieldName,
@@ -1761,7 +2343,7 @@ func extractSchemaFieldsFromProperties(schema *base.Schema, visited map[string]i
 								visited[itemSchemaName]++
 								itemSchemaActual := prop.Items.A.Schema()
 								if itemSchemaActual != nil {
-									nestedFields, nestedNested, err := extractSchemaFieldsFromProperties(itemSchemaActual, visited, maxDepth)
+									nestedFields, nestedNested, err := r.extractSchemaFieldsFromProperties(itemSchemaActual, visited, maxDepth)
 									if err != nil {
 										return nil, nil, err
 									}
@@ -1793,7 +2375,7 @@ func extractSchemaFieldsFromProperties(schema *base.Schema, visited map[string]i
 						// Check recursion
 						if visited[nestedSchemaName] <= 1 {
 							visited[nestedSchemaName]++
-							nestedFields, nestedNested, err := extractSchemaFieldsFromProperties(prop, visited, maxDepth)
+							nestedFields, nestedNested, err := r.extractSchemaFieldsFromProperties(prop, visited, maxDepth)
 							if err != nil {
 								return nil, nil, err
 							}
@@ -1820,7 +2402,7 @@ func extractSchemaFieldsFromProperties(schema *base.Schema, visited map[string]i
 }
 
 // extractSchemaFields recursively extracts field information from schema
-func extractSchemaFields(schemaProxy *base.SchemaProxy, examples map[string]json.RawMessage, visited map[string]int, maxDepth int) ([]schemaField, []schemaDefinition, error) {
+func (r *renderer) extractSchemaFields(schemaProxy *base.SchemaProxy, visited map[string]int, maxDepth int) ([]schemaField, []schemaDefinition, error) {
 	if schemaProxy == nil {
 		return nil, nil, nil
 	}
@@ -1881,6 +2463,9 @@ func extractSchemaFields(schemaProxy *base.SchemaProxy, examples map[string]json
 		}
 
 		prop := propSchema.Schema()
+		if r.isHiddenSchema(prop) {
+			continue
+		}
 
 		field := schemaField{
 			name:        fieldName,
@@ -1914,7 +2499,7 @@ func extractSchemaFields(schemaProxy *base.SchemaProxy, examples map[string]json
 							field.isObject = true
 
 							// Recursively extract nested schema
-							nestedFields, nestedNested, err := extractSchemaFields(prop.Items.A, examples, visited, maxDepth)
+							nestedFields, nestedNested, err := r.extractSchemaFields(prop.Items.A, visited, maxDepth)
 							if err != nil {
 								return nil, nil, err
 							}
@@ -1941,7 +2526,7 @@ func extractSchemaFields(schemaProxy *base.SchemaProxy, examples map[string]json
 						field.nestedSchemaRef = nestedSchemaName
 
 						// Recursively extract nested schema
-						nestedFields, nestedNested, err := extractSchemaFields(propSchema, examples, visited, maxDepth)
+						nestedFields, nestedNested, err := r.extractSchemaFields(propSchema, visited, maxDepth)
 						if err != nil {
 							return nil, nil, err
 						}
@@ -124,8 +124,8 @@ func TestConvertUnsupportedVersion(t *testing.T) {
 		wantErr string
 	}{
 		{
-			name: "openapi 2.0",
-			openapi: `swagger: "2.0"
+			name: "swagger 1.2",
+			openapi: `swagger: "1.2"
 info:
   title: Test API
   version: 1.0.0
@@ -133,7 +133,7 @@ paths: {}`,
 			opts: conv.ConvertOptions{
 				Title: "Test API",
 			},
-			wantErr: "only openapi 3.x is supported",
+			wantErr: "only openapi 3.x and swagger 2.0 are supported",
 		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
@@ -211,6 +211,508 @@ paths:
 	}
 }
 
+func TestConvertTableOfContentsGrouped(t *testing.T) {
+	const taggedSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      summary: List pets
+  /users:
+    get:
+      tags: [users]
+      summary: List users
+  /health:
+    get:
+      summary: Health check`
+
+	for _, test := range []struct {
+		name      string
+		openapi   string
+		opts      conv.ConvertOptions
+		wantMd    string
+		wantErr   string
+		notWantMd []string
+	}{
+		{
+			name:    "table per tag",
+			openapi: taggedSpec,
+			opts: conv.ConvertOptions{
+				Title: "Test API",
+			},
+			wantMd: "## Table of Contents\n\n" +
+				"[pets](#pets)\n\n" +
+				"HTTP Request | Description\n" +
+				"-------------|------------\n" +
+				"GET [/pets](#getpets) | List pets\n\n" +
+				"[users](#users)\n\n" +
+				"HTTP Request | Description\n" +
+				"-------------|------------\n" +
+				"GET [/users](#getusers) | List users\n\n" +
+				"[Default APIs](#default-apis)\n\n" +
+				"HTTP Request | Description\n" +
+				"-------------|------------\n" +
+				"GET [/health](#gethealth) | Health check\n\n" +
+				"## pets",
+		},
+		{
+			name:    "bullet list",
+			openapi: taggedSpec,
+			opts: conv.ConvertOptions{
+				Title:    "Test API",
+				TOCStyle: conv.TOCList,
+			},
+			wantMd: "## Table of Contents\n\n" +
+				"- [pets](#pets)\n" +
+				"  - GET [/pets](#getpets) - List pets\n" +
+				"- [users](#users)\n" +
+				"  - GET [/users](#getusers) - List users\n" +
+				"- [Default APIs](#default-apis)\n" +
+				"  - GET [/health](#gethealth) - Health check\n\n" +
+				"## pets",
+			notWantMd: []string{"HTTP Request | Description"},
+		},
+		{
+			name: "x-tagGroups",
+			openapi: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+x-tagGroups:
+  - name: Store
+    tags: [pets, orders]
+  - name: Accounts
+    tags: [users]
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      summary: List pets
+  /orders:
+    get:
+      tags: [orders]
+      summary: List orders
+  /users:
+    get:
+      tags: [users]
+      summary: List users
+  /health:
+    get:
+      summary: Health check`,
+			opts: conv.ConvertOptions{
+				Title:    "Test API",
+				TOCStyle: conv.TOCList,
+			},
+			wantMd: "## Table of Contents\n\n" +
+				"- Store\n" +
+				"  - [pets](#pets)\n" +
+				"    - GET [/pets](#getpets) - List pets\n" +
+				"  - [orders](#orders)\n" +
+				"    - GET [/orders](#getorders) - List orders\n" +
+				"- Accounts\n" +
+				"  - [users](#users)\n" +
+				"    - GET [/users](#getusers) - List users\n" +
+				"- [Default APIs](#default-apis)\n" +
+				"  - GET [/health](#gethealth) - Health check\n\n",
+		},
+		{
+			name: "tag anchors match GitHub heading slugs",
+			openapi: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      tags: [Pets & Owners]
+      summary: List pets
+  /users:
+    get:
+      tags: [users]
+      summary: List users`,
+			opts: conv.ConvertOptions{
+				Title:    "Test API",
+				TOCStyle: conv.TOCList,
+			},
+			wantMd: "- [Pets & Owners](#pets--owners)\n",
+		},
+		{
+			name: "shared schema definitions link",
+			openapi: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      summary: Create user
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+  /users/{id}:
+    put:
+      summary: Update user
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+          description: User name`,
+			opts: conv.ConvertOptions{
+				Title:               "Test API",
+				EnableSharedSchemas: true,
+			},
+			wantMd: "PUT [/users/{id}](#putusersid) | Update user\n\n" +
+				"[Shared Schema Definitions](#shared-schema-definitions)\n\n",
+		},
+		{
+			name:    "unsupported style",
+			openapi: taggedSpec,
+			opts: conv.ConvertOptions{
+				Title:    "Test API",
+				TOCStyle: "tree",
+			},
+			wantErr: "unsupported toc style: tree",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(test.openapi), test.opts)
+
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			md := string(result.Markdown)
+
+			assert.Contains(t, md, test.wantMd)
+			for _, notWant := range test.notWantMd {
+				assert.NotContains(t, md, notWant)
+			}
+		})
+	}
+}
+
+func TestConvertDeprecatedOperations(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      summary: List pets
+  /pets/search:
+    get:
+      tags: [pets]
+      summary: Search pets
+      deprecated: true
+      x-deprecated-sunset: "2026-01-01"
+      x-deprecated-replaced-by: GET /pets
+  /users:
+    get:
+      tags: [users]
+      summary: List users`
+
+	for _, test := range []struct {
+		name          string
+		opts          conv.ConvertOptions
+		wantMd        []string
+		notWantMd     []string
+		wantEndpoints int
+		wantErr       string
+	}{
+		{
+			name: "marked inline by default",
+			opts: conv.ConvertOptions{
+				Title: "Test API",
+			},
+			wantMd: []string{
+				"GET [/pets/search](#getpetssearch) | Search pets *(deprecated)*",
+				"### GET /pets/search (deprecated)\n\nSearch pets\n\n" +
+					"> **Deprecated:** This operation is deprecated and may be removed in a future version.\n" +
+					">\n> **Sunset:** 2026-01-01\n" +
+					">\n> **Replaced by:** GET /pets\n\n",
+			},
+			notWantMd:     []string{"## Deprecated"},
+			wantEndpoints: 3,
+		},
+		{
+			name: "hidden",
+			opts: conv.ConvertOptions{
+				Title:      "Test API",
+				Deprecated: conv.DeprecatedHide,
+			},
+			notWantMd:     []string{"/pets/search", "deprecated"},
+			wantEndpoints: 2,
+		},
+		{
+			name: "separate section",
+			opts: conv.ConvertOptions{
+				Title:              "Test API",
+				Deprecated:         conv.DeprecatedSection,
+				DisableCodeSamples: true,
+			},
+			wantMd: []string{
+				"[Deprecated](#deprecated)\n\n" +
+					"HTTP Request | Description\n" +
+					"-------------|------------\n" +
+					"GET [/pets/search](#getpetssearch) | Search pets *(deprecated)*\n\n",
+				"## users\n\n### GET /users\n\nList users\n\n## Deprecated\n\n### GET /pets/search (deprecated)",
+			},
+			notWantMd:     []string{"GET [/pets/search](#getpetssearch) | Search pets *(deprecated)*\nGET [/users]"},
+			wantEndpoints: 3,
+		},
+		{
+			name: "unsupported mode",
+			opts: conv.ConvertOptions{
+				Title:      "Test API",
+				Deprecated: "remove",
+			},
+			wantErr: "unsupported deprecated mode: remove",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(spec), test.opts)
+
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			md := string(result.Markdown)
+
+			for _, want := range test.wantMd {
+				assert.Contains(t, md, want)
+			}
+			for _, notWant := range test.notWantMd {
+				assert.NotContains(t, md, notWant)
+			}
+			assert.Equal(t, test.wantEndpoints, result.EndpointCount)
+		})
+	}
+}
+
+func TestConvertDeprecationNoticeValues(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets/search:
+    get:
+      summary: Search pets
+      deprecated: true
+      x-deprecated-sunset: 2025-01-01
+      x-deprecated-version: 1.10
+      x-deprecated-alternatives: [GET /pets, GET /animals]
+      x-deprecated-échéance: 2025-06-01`
+
+	result, err := conv.Convert([]byte(spec), conv.ConvertOptions{Title: "Test API"})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(result.Markdown),
+		"> **Deprecated:** This operation is deprecated and may be removed in a future version.\n"+
+			">\n> **Sunset:** 2025-01-01\n"+
+			">\n> **Version:** 1.10\n"+
+			">\n> **Alternatives:** [GET /pets GET /animals]\n"+
+			">\n> **Échéance:** 2025-06-01\n\n")
+}
+
+func TestConvertFormParameters(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /photos:
+    post:
+      summary: Upload photo
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              required: [file]
+              properties:
+                file:
+                  type: string
+                  format: binary
+                  description: Photo to upload
+                caption:
+                  type: string
+                size:
+                  type: string
+                  enum: [small, large]
+                  description: Thumbnail size
+      responses:
+        '204':
+          description: Uploaded`
+
+	result, err := conv.Convert([]byte(spec), conv.ConvertOptions{Title: "Test API", DisableCodeSamples: true})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(result.Markdown),
+		"### Request\n\n#### Form Parameters\n\nContent type: `multipart/form-data`\n\n"+
+			"- `file` *(file, required)* Photo to upload\n"+
+			"- `caption` *(string)*\n"+
+			"- `size` *(string)* Thumbnail size Enums: `small`, `large`\n\n"+
+			"### Responses")
+
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, conv.WarningMissingFieldDescription, result.Warnings[0].Code)
+	assert.Equal(t, "/paths/~1photos/post/requestBody/content/multipart~1form-data/schema/properties/caption", result.Warnings[0].Pointer)
+	assert.Equal(t, "POST /photos", result.Warnings[0].Operation)
+}
+
+func TestConvertHeadingOffset(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    put:
+      tags: [users]
+      summary: Update user
+      parameters:
+        - name: id
+          in: path
+          required: true
+          description: User ID
+          schema:
+            type: string
+        - name: dryRun
+          in: query
+          description: Validate only
+          schema:
+            type: boolean
+        - name: X-Request-ID
+          in: header
+          description: Request ID
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+      responses:
+        '200':
+          description: Updated
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /users:
+    post:
+      tags: [admin]
+      summary: Create user
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+          description: User name`
+
+	for _, test := range []struct {
+		name    string
+		offset  int
+		wantMd  []string
+		wantErr string
+	}{
+		{
+			name:   "no offset",
+			offset: 0,
+			wantMd: []string{
+				"# Test API\n",
+				"\n## Table of Contents\n",
+				"\n## users\n",
+				"\n### PUT /users/{id}\n",
+				"\n#### Path Parameters\n",
+				"\n#### Query Parameters\n",
+				"\n#### Headers\n",
+				"\n### Request\n",
+				"\n### Responses\n",
+				"\n#### 200 Response\n",
+				"\n## Shared Schema Definitions\n",
+				"\n### User\n",
+			},
+		},
+		{
+			name:   "offset by two",
+			offset: 2,
+			wantMd: []string{
+				"### Test API\n",
+				"\n#### Table of Contents\n",
+				"\n#### users\n",
+				"\n##### PUT /users/{id}\n",
+				"\n###### Path Parameters\n",
+				"\n###### Query Parameters\n",
+				"\n###### Headers\n",
+				"\n##### Request\n",
+				"\n##### Responses\n",
+				"\n###### 200 Response\n",
+				"\n#### Shared Schema Definitions\n",
+				"\n##### User\n",
+			},
+		},
+		{
+			name:    "offset past six levels",
+			offset:  3,
+			wantErr: "heading offset 3 pushes headings past level 6, the maximum is 2",
+		},
+		{
+			name:    "negative offset",
+			offset:  -1,
+			wantErr: "heading offset cannot be negative",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(spec), conv.ConvertOptions{
+				Title:               "Test API",
+				EnableSharedSchemas: true,
+				HeadingOffset:       test.offset,
+			})
+
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			md := string(result.Markdown)
+
+			assert.True(t, strings.HasPrefix(md, test.wantMd[0]))
+			for _, want := range test.wantMd[1:] {
+				assert.Contains(t, md, want)
+			}
+		})
+	}
+}
+
 func TestConvertSingleEndpoint(t *testing.T) {
 	for _, test := range []struct {
 		name    string
@@ -703,6 +1205,118 @@ components:
 	}
 }
 
+func TestConvertNamedExamples(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /orders:
+    post:
+      summary: Create order
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Order'
+            examples:
+              minimal:
+                summary: Minimal order
+                value:
+                  item: book
+              full:
+                summary: Full order
+                description: Every field set, including the optional quantity
+                value:
+                  item: book
+                  quantity: 2
+              discount:
+                value:
+                  item: book
+                  coupon: SAVE10
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Order'
+              examples:
+                created:
+                  summary: Created order
+                  value:
+                    item: book
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        item:
+          type: string
+          description: Item ordered
+        quantity:
+          type: integer
+          description: Number of items
+        coupon:
+          type: string
+          description: Discount coupon`
+
+	for _, test := range []struct {
+		name      string
+		opts      conv.ConvertOptions
+		wantMd    []string
+		notWantMd []string
+		wantErr   string
+	}{
+		{
+			name: "all named examples",
+			opts: conv.ConvertOptions{Title: "Test API"},
+			wantMd: []string{
+				"### Request\n\n" +
+					"**Minimal order**\n\n```json\n{\n   \"item\": \"book\"\n}\n```\n\n" +
+					"**Full order**\n\nEvery field set, including the optional quantity\n\n" +
+					"```json\n{\n   \"item\": \"book\",\n   \"quantity\": 2\n}\n```\n\n" +
+					"**discount**\n\n```json\n{\n   \"coupon\": \"SAVE10\",\n   \"item\": \"book\"\n}\n```\n\n",
+				"#### 201 Response\n\nCreated\n\n**Created order**\n\n```json\n{\n   \"item\": \"book\"\n}\n```\n\n",
+				// Code samples send the first example
+				"-d '{\n   \"item\": \"book\"\n}'",
+			},
+		},
+		{
+			name: "capped",
+			opts: conv.ConvertOptions{Title: "Test API", MaxExamples: 2},
+			wantMd: []string{
+				"**Minimal order**",
+				"**Full order**",
+				"**Created order**",
+			},
+			notWantMd: []string{"**discount**", "SAVE10"},
+		},
+		{
+			name:    "negative cap",
+			opts:    conv.ConvertOptions{Title: "Test API", MaxExamples: -1},
+			wantErr: "max examples cannot be negative",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(spec), test.opts)
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			md := string(result.Markdown)
+
+			for _, want := range test.wantMd {
+				assert.Contains(t, md, want)
+			}
+			for _, notWant := range test.notWantMd {
+				assert.NotContains(t, md, notWant)
+			}
+		})
+	}
+}
+
 func TestConvertSchemaPropertyExamples(t *testing.T) {
 	for _, test := range []struct {
 		name       string
@@ -2810,6 +3424,129 @@ paths:
 	}
 }
 
+func TestConvertParameterExamples(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		openapi   string
+		wantMd    []string
+		notWantMd []string
+	}{
+		{
+			name: "examples inline and in the example URL",
+			openapi: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+servers:
+  - url: https://api.example.com
+paths:
+  /users/{id}:
+    get:
+      summary: Get user
+      parameters:
+        - name: id
+          in: path
+          required: true
+          description: User ID
+          example: u-42
+          schema:
+            type: string
+        - name: expand
+          in: query
+          description: Related objects to include
+          examples:
+            orders:
+              value: [orders, payments]
+          schema:
+            type: array
+            items:
+              type: string
+        - name: sort
+          in: query
+          schema:
+            type: string
+            example: name
+        - name: page
+          in: query
+          description: Page number
+          schema:
+            type: integer
+        - name: X-Tenant
+          in: header
+          description: Tenant identifier
+          example: acme
+          schema:
+            type: string
+        - name: X-Trace
+          in: header
+          example: abc123
+          schema:
+            type: string`,
+			wantMd: []string{
+				"- `id` *(string, required)* User ID Example: `u-42`\n",
+				"- `expand` *(array)* Related objects to include Example: `orders,payments`\n",
+				"- `sort` *(string)* Example: `name`\n",
+				"- `page` *(integer)* Page number\n",
+				"Example URL: `https://api.example.com/users/u-42?expand=orders%2Cpayments&sort=name`\n\n#### Headers",
+				"X-Tenant | Tenant identifier Example: `acme` | false | string\n",
+				"X-Trace | Example: `abc123` | false | string\n",
+			},
+			notWantMd: []string{"page="},
+		},
+		{
+			name: "no example URL without path or query parameters",
+			openapi: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      summary: List users
+      parameters:
+        - name: X-Tenant
+          in: header
+          schema:
+            type: string`,
+			notWantMd: []string{"Example URL"},
+		},
+		{
+			name: "header table cells escape pipes and line breaks",
+			openapi: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      summary: List users
+      parameters:
+        - name: X-Filter
+          in: header
+          description: |
+            Filter expression,
+            such as a|b
+          example: a|b
+          schema:
+            type: string`,
+			wantMd: []string{"X-Filter | Filter expression, such as a\\|b Example: `a\\|b` | false | string\n"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(test.openapi), conv.ConvertOptions{Title: "Test API"})
+			require.NoError(t, err)
+			md := string(result.Markdown)
+
+			for _, want := range test.wantMd {
+				assert.Contains(t, md, want)
+			}
+			for _, notWant := range test.notWantMd {
+				assert.NotContains(t, md, notWant)
+			}
+		})
+	}
+}
+
 func TestConvertSharedSchemaAcrossEndpoints(t *testing.T) {
 	for _, test := range []struct {
 		name    string
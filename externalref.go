@@ -0,0 +1,332 @@
+package conv
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// schemaRefPrefix is the prefix of references to component schemas
+const schemaRefPrefix = "#/components/schemas/"
+
+// externalRefs bundles a multi-file spec into a single document. Schemas referenced from other
+// files are added to components/schemas so they are named, documented and shared exactly like
+// local schemas; other referenced objects (parameters, responses, ...) are inlined.
+type externalRefs struct {
+	opts ConvertOptions
+	// schemas is the components/schemas mapping of the root document
+	schemas *yaml.Node
+	// docs caches parsed external files by path
+	docs map[string]*yaml.Node
+	// names maps a resolved "file#fragment" reference to its component schema name
+	names map[string]string
+	// used holds every component schema name already taken
+	used    map[string]bool
+	changed bool
+}
+
+// bundleExternalRefs resolves $ref values pointing into other files relative to
+// ConvertOptions.BasePath or ConvertOptions.FS and returns a single self-contained document.
+// The input is returned unchanged when it has no external references.
+func bundleExternalRefs(openapi []byte, opts ConvertOptions) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(openapi, &doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		// Leave reporting malformed documents to libopenapi
+		return openapi, nil
+	}
+
+	b := &externalRefs{
+		opts:  opts,
+		docs:  make(map[string]*yaml.Node),
+		names: make(map[string]string),
+		used:  make(map[string]bool),
+	}
+
+	root := doc.Content[0]
+	if components := mappingValue(root, "components"); components != nil {
+		b.schemas = mappingValue(components, "schemas")
+	}
+	if b.schemas != nil {
+		for i := 0; i+1 < len(b.schemas.Content); i += 2 {
+			b.used[b.schemas.Content[i].Value] = true
+		}
+	}
+
+	if err := b.walk(root, "", false); err != nil {
+		return nil, err
+	}
+
+	if !b.changed {
+		return openapi, nil
+	}
+
+	if b.schemas != nil && len(b.schemas.Content) > 0 {
+		if components := mappingValue(root, "components"); components == nil {
+			root.Content = append(root.Content, scalarNode("components"), &yaml.Node{Kind: yaml.MappingNode})
+		}
+		components := mappingValue(root, "components")
+		if mappingValue(components, "schemas") == nil {
+			components.Content = append(components.Content, scalarNode("schemas"), b.schemas)
+		}
+	}
+
+	bundled, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bundle external references: %w", err)
+	}
+	return bundled, nil
+}
+
+// walk rewrites the external references found in node, which belongs to file ("" for the root
+// document). schema reports whether node is a schema object.
+func (b *externalRefs) walk(node *yaml.Node, file string, schema bool) error {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := b.walk(child, file, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	case yaml.MappingNode:
+	default:
+		return nil
+	}
+
+	if ref := mappingValue(node, "$ref"); ref != nil && ref.Kind == yaml.ScalarNode {
+		return b.rewriteRef(node, ref, file, schema)
+	}
+
+	// Iterate over the original entries only; lifting schemas may append to components/schemas
+	for i, n := 0, len(node.Content); i+1 < n; i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+
+		var err error
+		switch {
+		case key == "schema" || (schema && (key == "items" || key == "additionalProperties" || key == "not")):
+			err = b.walk(value, file, true)
+		case key == "schemas" || (schema && key == "properties"):
+			err = b.walkEach(value, file)
+		case schema && (key == "allOf" || key == "oneOf" || key == "anyOf"):
+			err = b.walkEach(value, file)
+		case schema && key == "example":
+			// Examples are data, never references
+		default:
+			err = b.walk(value, file, false)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkEach walks every value of a mapping or sequence of schemas
+func (b *externalRefs) walkEach(node *yaml.Node, file string) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i, n := 0, len(node.Content); i+1 < n; i += 2 {
+			if err := b.walk(node.Content[i+1], file, true); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := b.walk(child, file, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rewriteRef points a schema reference at the component schema lifted from the referenced file,
+// or replaces any other reference with a copy of its target
+func (b *externalRefs) rewriteRef(node, ref *yaml.Node, file string, schema bool) error {
+	target, fragment, _ := strings.Cut(ref.Value, "#")
+	if target == "" && file == "" {
+		// Local reference within the root document
+		return nil
+	}
+	if strings.Contains(target, "://") {
+		return fmt.Errorf("remote reference %s is not supported", ref.Value)
+	}
+
+	resolved := file
+	if target != "" {
+		resolved = resolveRefPath(file, target)
+	}
+
+	if schema {
+		name, err := b.liftSchema(resolved, fragment)
+		if err != nil {
+			return err
+		}
+		ref.Value = schemaRefPrefix + name
+		b.changed = true
+		return nil
+	}
+
+	content, err := b.resolve(resolved, fragment)
+	if err != nil {
+		return err
+	}
+
+	inlined := copyNode(content)
+	if err := b.walk(inlined, resolved, false); err != nil {
+		return err
+	}
+	*node = *inlined
+	b.changed = true
+	return nil
+}
+
+// liftSchema adds the schema at file#fragment to components/schemas and returns its name.
+// Each referenced schema is added once, named after the last fragment segment or the file.
+func (b *externalRefs) liftSchema(file, fragment string) (string, error) {
+	key := file + "#" + fragment
+	if name, ok := b.names[key]; ok {
+		return name, nil
+	}
+
+	content, err := b.resolve(file, fragment)
+	if err != nil {
+		return "", err
+	}
+
+	base := externalSchemaName(file, fragment)
+	name := base
+	for i := 2; b.used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	b.names[key] = name
+	b.used[name] = true
+
+	schema := copyNode(content)
+	if b.schemas == nil {
+		b.schemas = &yaml.Node{Kind: yaml.MappingNode}
+	}
+	b.schemas.Content = append(b.schemas.Content, scalarNode(name), schema)
+
+	// Registered before walking so that recursive references resolve to the same schema
+	return name, b.walk(schema, file, true)
+}
+
+// resolve returns the node at the JSON pointer fragment of an external file
+func (b *externalRefs) resolve(file, fragment string) (*yaml.Node, error) {
+	doc, ok := b.docs[file]
+	if !ok {
+		data, err := b.read(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve reference %s: %w", file, err)
+		}
+
+		var parsed yaml.Node
+		if err := yaml.Unmarshal(data, &parsed); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if len(parsed.Content) == 0 {
+			return nil, fmt.Errorf("referenced file %s is empty", file)
+		}
+
+		doc = parsed.Content[0]
+		b.docs[file] = doc
+	}
+
+	node := doc
+	for _, segment := range strings.Split(strings.TrimPrefix(fragment, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		segment = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+
+		switch node.Kind {
+		case yaml.MappingNode:
+			node = mappingValue(node, segment)
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node.Content) {
+				node = nil
+			} else {
+				node = node.Content[index]
+			}
+		default:
+			node = nil
+		}
+
+		if node == nil {
+			return nil, fmt.Errorf("reference %s#%s cannot be found", file, fragment)
+		}
+	}
+
+	return node, nil
+}
+
+// read loads an external file from ConvertOptions.FS, or from disk relative to BasePath
+func (b *externalRefs) read(file string) ([]byte, error) {
+	switch {
+	case b.opts.FS != nil:
+		return fs.ReadFile(b.opts.FS, file)
+	case b.opts.BasePath != "":
+		if filepath.IsAbs(file) {
+			return os.ReadFile(file)
+		}
+		return os.ReadFile(filepath.Join(b.opts.BasePath, filepath.FromSlash(file)))
+	default:
+		return nil, fmt.Errorf("external references require ConvertOptions.BasePath or FS")
+	}
+}
+
+// resolveRefPath resolves a referenced file path relative to the file containing the reference
+func resolveRefPath(file, target string) string {
+	if path.IsAbs(target) {
+		return path.Clean(target)
+	}
+	return path.Join(path.Dir(file), target)
+}
+
+// externalSchemaName names a schema referenced from another file after the last segment of the
+// fragment (e.g. "./schemas/pet.yaml#/Pet" -> "Pet"), or after the file when the reference
+// points at the whole file (e.g. "./owner.yaml" -> "owner")
+func externalSchemaName(file, fragment string) string {
+	if i := strings.LastIndex(fragment, "/"); i != -1 && i < len(fragment)-1 {
+		return fragment[i+1:]
+	}
+	base := path.Base(file)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// mappingValue returns the value stored under key in a mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalarNode creates a string scalar node
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// copyNode deep copies a node so the same external content can be placed in several locations
+func copyNode(node *yaml.Node) *yaml.Node {
+	copied := *node
+	copied.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		copied.Content[i] = copyNode(child)
+	}
+	return &copied
+}
@@ -0,0 +1,152 @@
+package conv_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const externalRefSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      summary: List pets
+      parameters:
+        - $ref: './common/parameters.yaml#/Limit'
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: './schemas/pet.yaml#/Pet'
+    post:
+      summary: Create pet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: './schemas/pet.yaml#/Pet'
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: './schemas/pet.yaml#/Pet'`
+
+var externalRefFiles = map[string]string{
+	"schemas/pet.yaml": `Pet:
+  type: object
+  properties:
+    name:
+      type: string
+      description: Name of the pet
+    owner:
+      $ref: './owner.yaml'
+    tags:
+      type: array
+      items:
+        $ref: '#/Tag'
+Tag:
+  type: object
+  properties:
+    label:
+      type: string
+      description: Tag label`,
+	"schemas/owner.yaml": `type: object
+properties:
+  id:
+    type: string
+    description: Owner identifier`,
+	"common/parameters.yaml": `Limit:
+  name: limit
+  in: query
+  description: Maximum number of pets to return
+  schema:
+    type: integer`,
+}
+
+func TestConvertExternalRefs(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for name, content := range externalRefFiles {
+		fsys[name] = &fstest.MapFile{Data: []byte(content)}
+	}
+
+	dir := t.TempDir()
+	for name, content := range externalRefFiles {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	for _, test := range []struct {
+		name       string
+		opts       conv.ConvertOptions
+		wantSubstr []string
+		wantErr    string
+	}{
+		{
+			name: "resolves from fs",
+			opts: conv.ConvertOptions{Title: "Test API", FS: fsys},
+			wantSubstr: []string{
+				"- `name` *(string)* Name of the pet",
+				"- `owner` *(owner)*",
+				"**owner**\n- `id` *(string)*: Owner identifier",
+				"- `tags` *(array of Tag)*",
+				"**Tag**\n- `label` *(string)*: Tag label",
+				"- `limit` *(integer)* Maximum number of pets to return",
+			},
+		},
+		{
+			name: "resolves from base path",
+			opts: conv.ConvertOptions{Title: "Test API", BasePath: dir},
+			wantSubstr: []string{
+				"- `name` *(string)* Name of the pet",
+				"**owner**\n- `id` *(string)*: Owner identifier",
+			},
+		},
+		{
+			name: "shared external schemas",
+			opts: conv.ConvertOptions{Title: "Test API", FS: fsys, EnableSharedSchemas: true},
+			wantSubstr: []string{
+				"## Shared Schema Definitions",
+				"### Pet",
+				"See [Pet](#pet)",
+			},
+		},
+		{
+			name:    "requires a base path",
+			opts:    conv.ConvertOptions{Title: "Test API"},
+			wantErr: "external references require ConvertOptions.BasePath or FS",
+		},
+		{
+			name:    "missing file",
+			opts:    conv.ConvertOptions{Title: "Test API", FS: fstest.MapFS{}},
+			wantErr: "failed to resolve reference common/parameters.yaml",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(externalRefSpec), test.opts)
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			md := string(result.Markdown)
+			for _, want := range test.wantSubstr {
+				assert.Contains(t, md, want)
+			}
+		})
+	}
+}
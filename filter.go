@@ -0,0 +1,169 @@
+package conv
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// OperationFilter selects operations by tag, path, HTTP method, operationId or vendor extension.
+// Criteria left empty are ignored.
+type OperationFilter struct {
+	// Tags matches operations carrying any of the listed tags
+	Tags []string
+	// Paths matches operation paths against glob patterns, where `*` matches within a single
+	// path segment and `**` matches across segments (e.g. "/admin/**")
+	Paths []string
+	// Methods matches HTTP methods, case-insensitively
+	Methods []string
+	// OperationIDs matches operations by operationId
+	OperationIDs []string
+	// Extensions matches vendor extensions on the operation by value (e.g. "x-internal": "true").
+	// An empty value matches any operation that declares the extension.
+	Extensions map[string]string
+}
+
+// isEmpty reports whether the filter has no criteria
+func (f OperationFilter) isEmpty() bool {
+	return len(f.Tags) == 0 && len(f.Paths) == 0 && len(f.Methods) == 0 &&
+		len(f.OperationIDs) == 0 && len(f.Extensions) == 0
+}
+
+// matches evaluates every non-empty criterion of the filter against an endpoint
+func (f OperationFilter) matches(e endpoint) []bool {
+	var results []bool
+
+	if len(f.Tags) > 0 {
+		matched := false
+		for _, tag := range e.tags {
+			if slices.Contains(f.Tags, tag) {
+				matched = true
+				break
+			}
+		}
+		results = append(results, matched)
+	}
+
+	if len(f.Paths) > 0 {
+		matched := false
+		for _, pattern := range f.Paths {
+			if matchPathGlob(pattern, e.path) {
+				matched = true
+				break
+			}
+		}
+		results = append(results, matched)
+	}
+
+	if len(f.Methods) > 0 {
+		matched := false
+		for _, method := range f.Methods {
+			if strings.EqualFold(method, e.method) {
+				matched = true
+				break
+			}
+		}
+		results = append(results, matched)
+	}
+
+	if len(f.OperationIDs) > 0 {
+		matched := e.operation != nil && e.operation.OperationId != "" &&
+			slices.Contains(f.OperationIDs, e.operation.OperationId)
+		results = append(results, matched)
+	}
+
+	if len(f.Extensions) > 0 {
+		matched := false
+		for key, want := range f.Extensions {
+			if value, ok := operationExtension(e, key); ok && (want == "" || value == want) {
+				matched = true
+				break
+			}
+		}
+		results = append(results, matched)
+	}
+
+	return results
+}
+
+// filterEndpoints keeps endpoints that match every include criterion and no exclude criterion
+func filterEndpoints(endpoints []endpoint, include, exclude OperationFilter) []endpoint {
+	if include.isEmpty() && exclude.isEmpty() {
+		return endpoints
+	}
+
+	var filtered []endpoint
+
+	for _, e := range endpoints {
+		included := true
+		for _, matched := range include.matches(e) {
+			if !matched {
+				included = false
+				break
+			}
+		}
+		if !included {
+			continue
+		}
+
+		excluded := false
+		for _, matched := range exclude.matches(e) {
+			if matched {
+				excluded = true
+				break
+			}
+		}
+		if excluded {
+			continue
+		}
+
+		filtered = append(filtered, e)
+	}
+
+	return filtered
+}
+
+// operationExtension returns the scalar value of a vendor extension declared on an operation
+func operationExtension(e endpoint, key string) (string, bool) {
+	if e.operation == nil || e.operation.Extensions == nil {
+		return "", false
+	}
+
+	node := e.operation.Extensions.GetOrZero(key)
+	if node == nil {
+		return "", false
+	}
+
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return node.Value, true
+	}
+
+	return fmt.Sprintf("%v", value), true
+}
+
+// matchPathGlob reports whether path matches a glob pattern where `*` and `?` stay within
+// a path segment and `**` spans segments
+func matchPathGlob(pattern, path string) bool {
+	var expr strings.Builder
+	expr.WriteString("^")
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			expr.WriteString(".*")
+			i++
+		case runes[i] == '*':
+			expr.WriteString("[^/]*")
+		case runes[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(runes[i])))
+		}
+	}
+
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String()).MatchString(path)
+}
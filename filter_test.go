@@ -0,0 +1,142 @@
+package conv_test
+
+import (
+	"testing"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertOperationFilters(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      operationId: listPets
+      summary: List pets
+    post:
+      tags: [pets]
+      operationId: createPet
+      summary: Create pet
+  /admin/pets/{id}:
+    delete:
+      tags: [admin]
+      operationId: deletePet
+      summary: Delete pet
+      x-internal: true
+  /admin/stats:
+    get:
+      tags: [admin]
+      operationId: getStats
+      summary: Get stats
+      x-audience: ops`
+
+	for _, test := range []struct {
+		name       string
+		opts       conv.ConvertOptions
+		wantOps    []string
+		notWantOps []string
+	}{
+		{
+			name: "no filters",
+			opts: conv.ConvertOptions{
+				Title: "Test API",
+			},
+			wantOps: []string{"GET /pets", "POST /pets", "DELETE /admin/pets/{id}", "GET /admin/stats"},
+		},
+		{
+			name: "include by tag",
+			opts: conv.ConvertOptions{
+				Title:   "Test API",
+				Include: conv.OperationFilter{Tags: []string{"pets"}},
+			},
+			wantOps:    []string{"GET /pets", "POST /pets"},
+			notWantOps: []string{"DELETE /admin/pets/{id}", "GET /admin/stats"},
+		},
+		{
+			name: "include requires every criterion",
+			opts: conv.ConvertOptions{
+				Title:   "Test API",
+				Include: conv.OperationFilter{Tags: []string{"pets"}, Methods: []string{"get"}},
+			},
+			wantOps:    []string{"GET /pets"},
+			notWantOps: []string{"POST /pets", "DELETE /admin/pets/{id}", "GET /admin/stats"},
+		},
+		{
+			name: "exclude by path glob across segments",
+			opts: conv.ConvertOptions{
+				Title:   "Test API",
+				Exclude: conv.OperationFilter{Paths: []string{"/admin/**"}},
+			},
+			wantOps:    []string{"GET /pets", "POST /pets"},
+			notWantOps: []string{"DELETE /admin/pets/{id}", "GET /admin/stats"},
+		},
+		{
+			name: "single star stays within a segment",
+			opts: conv.ConvertOptions{
+				Title:   "Test API",
+				Exclude: conv.OperationFilter{Paths: []string{"/admin/*"}},
+			},
+			wantOps:    []string{"GET /pets", "POST /pets", "DELETE /admin/pets/{id}"},
+			notWantOps: []string{"GET /admin/stats"},
+		},
+		{
+			name: "exclude by operationId",
+			opts: conv.ConvertOptions{
+				Title:   "Test API",
+				Exclude: conv.OperationFilter{OperationIDs: []string{"createPet"}},
+			},
+			wantOps:    []string{"GET /pets", "DELETE /admin/pets/{id}", "GET /admin/stats"},
+			notWantOps: []string{"POST /pets"},
+		},
+		{
+			name: "exclude by extension value",
+			opts: conv.ConvertOptions{
+				Title:   "Test API",
+				Exclude: conv.OperationFilter{Extensions: map[string]string{"x-internal": "true"}},
+			},
+			wantOps:    []string{"GET /pets", "POST /pets", "GET /admin/stats"},
+			notWantOps: []string{"DELETE /admin/pets/{id}"},
+		},
+		{
+			name: "exclude by extension presence",
+			opts: conv.ConvertOptions{
+				Title:   "Test API",
+				Exclude: conv.OperationFilter{Extensions: map[string]string{"x-audience": ""}},
+			},
+			wantOps:    []string{"GET /pets", "POST /pets", "DELETE /admin/pets/{id}"},
+			notWantOps: []string{"GET /admin/stats"},
+		},
+		{
+			name: "exclude matches any criterion",
+			opts: conv.ConvertOptions{
+				Title: "Test API",
+				Exclude: conv.OperationFilter{
+					Methods:      []string{"POST"},
+					OperationIDs: []string{"getStats"},
+				},
+			},
+			wantOps:    []string{"GET /pets", "DELETE /admin/pets/{id}"},
+			notWantOps: []string{"POST /pets", "GET /admin/stats"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(spec), test.opts)
+			require.NoError(t, err)
+
+			md := string(result.Markdown)
+			for _, op := range test.wantOps {
+				assert.Contains(t, md, "# "+op+"\n")
+			}
+			for _, op := range test.notWantOps {
+				assert.NotContains(t, md, "# "+op+"\n")
+			}
+			assert.Equal(t, len(test.wantOps), result.EndpointCount)
+		})
+	}
+}
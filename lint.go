@@ -0,0 +1,396 @@
+package conv
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"go.yaml.in/yaml/v4"
+)
+
+// LintRule identifies a documentation lint rule
+type LintRule string
+
+const (
+	// LintOperationDescription reports operations without a summary or description
+	LintOperationDescription LintRule = "operation-description"
+	// LintParameterDescription reports parameters without a description
+	LintParameterDescription LintRule = "parameter-description"
+	// LintFieldDescription reports schema properties without a description
+	LintFieldDescription LintRule = "field-description"
+	// LintEnumDescription reports enums without a description explaining their values
+	LintEnumDescription LintRule = "enum-description"
+	// LintResponseExample reports JSON responses that rely on generated examples
+	LintResponseExample LintRule = "response-example"
+	// LintErrorResponse reports operations that document no 4xx, 5xx or default response
+	LintErrorResponse LintRule = "error-response"
+	// LintUnusedSchema reports component schemas that nothing references
+	LintUnusedSchema LintRule = "unused-schema"
+)
+
+// lintRules lists every rule in reporting order with its description and default severity
+var lintRules = []struct {
+	rule        LintRule
+	description string
+	severity    Severity
+}{
+	{LintOperationDescription, "Operations should have a summary or description", SeverityWarning},
+	{LintParameterDescription, "Parameters should have a description", SeverityWarning},
+	{LintFieldDescription, "Schema properties should have a description", SeverityWarning},
+	{LintEnumDescription, "Enums should have a description explaining their values", SeverityWarning},
+	{LintResponseExample, "JSON responses should have an example", SeverityInfo},
+	{LintErrorResponse, "Operations should document their error responses", SeverityWarning},
+	{LintUnusedSchema, "Component schemas should be referenced", SeverityWarning},
+}
+
+// LintRules returns every lint rule
+func LintRules() []LintRule {
+	rules := make([]LintRule, len(lintRules))
+	for i, info := range lintRules {
+		rules[i] = info.rule
+	}
+	return rules
+}
+
+// Description explains what the rule checks
+func (r LintRule) Description() string {
+	for _, info := range lintRules {
+		if info.rule == r {
+			return info.description
+		}
+	}
+	return ""
+}
+
+// DefaultSeverity returns the severity of the rule when LintOptions.Rules does not set one
+func (r LintRule) DefaultSeverity() Severity {
+	for _, info := range lintRules {
+		if info.rule == r {
+			return info.severity
+		}
+	}
+	return SeverityOff
+}
+
+// Severity is the level a lint rule reports its findings at
+type Severity string
+
+const (
+	// SeverityError marks findings that should fail a build
+	SeverityError Severity = "error"
+	// SeverityWarning marks documentation gaps worth fixing
+	SeverityWarning Severity = "warning"
+	// SeverityInfo marks suggestions
+	SeverityInfo Severity = "info"
+	// SeverityOff disables a rule
+	SeverityOff Severity = "off"
+)
+
+// LintOptions configures Lint
+type LintOptions struct {
+	// ConvertOptions selects the operations to lint (filters, audiences and deprecated handling)
+	// and resolves external references. Title is not required.
+	ConvertOptions
+	// Rules sets the severity of individual rules, SeverityOff disables a rule. Rules that are
+	// not listed report at their default severity.
+	Rules map[LintRule]Severity
+}
+
+// LintFinding is a documentation problem reported by a lint rule
+type LintFinding struct {
+	Rule     LintRule
+	Severity Severity
+	Message  string
+	// Pointer is a JSON pointer to the part of the spec the finding is about
+	Pointer string
+	// Operation is the operation the finding belongs to (e.g. "GET /pets"), empty for findings
+	// about component schemas
+	Operation string
+}
+
+// String formats the finding as "pointer: message"
+func (f LintFinding) String() string {
+	if f.Pointer == "" {
+		return f.Message
+	}
+	return f.Pointer + ": " + f.Message
+}
+
+// LintResult contains the findings of every enabled rule
+type LintResult struct {
+	Findings []LintFinding
+}
+
+// Lint checks an OpenAPI 3.x or Swagger 2.0 spec for documentation gaps
+func Lint(openapi []byte, opts LintOptions) (*LintResult, error) {
+	if len(openapi) == 0 {
+		return nil, fmt.Errorf("openapi input cannot be empty")
+	}
+
+	for rule, severity := range opts.Rules {
+		if rule.Description() == "" {
+			return nil, fmt.Errorf("unknown lint rule: %s", rule)
+		}
+		switch severity {
+		case SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+		default:
+			return nil, fmt.Errorf("unsupported severity for %s: %s", rule, severity)
+		}
+	}
+
+	model, openapi, err := loadModel(openapi, opts.ConvertOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &linter{opts: opts}
+	for _, e := range selectEndpoints(*model, opts.ConvertOptions) {
+		l.lintOperation(e)
+	}
+
+	if model.Components != nil && model.Components.Schemas != nil {
+		for pair := model.Components.Schemas.First(); pair != nil; pair = pair.Next() {
+			schema := pair.Value().Schema()
+			if schema == nil {
+				continue
+			}
+			pointer := schemaPointer(pair.Key())
+			if len(schema.Enum) > 0 && schema.Description == "" {
+				l.report(LintEnumDescription, pointer, "", "enum schema %q has no description", pair.Key())
+			}
+			l.lintSchema(schema, pointer)
+		}
+	}
+
+	for _, name := range unusedSchemas(openapi) {
+		l.report(LintUnusedSchema, schemaPointer(name), "", "schema %q is not referenced", name)
+	}
+
+	return &LintResult{Findings: l.findings}, nil
+}
+
+// linter collects the findings of the enabled rules
+type linter struct {
+	opts     LintOptions
+	findings []LintFinding
+}
+
+// report records a finding unless the rule is disabled
+func (l *linter) report(rule LintRule, pointer, operation string, format string, args ...any) {
+	severity, ok := l.opts.Rules[rule]
+	if !ok {
+		severity = rule.DefaultSeverity()
+	}
+	if severity == SeverityOff {
+		return
+	}
+
+	l.findings = append(l.findings, LintFinding{
+		Rule:      rule,
+		Severity:  severity,
+		Message:   fmt.Sprintf(format, args...),
+		Pointer:   pointer,
+		Operation: operation,
+	})
+}
+
+// lintOperation checks the description, parameters and responses of an operation
+func (l *linter) lintOperation(e endpoint) {
+	key := endpointKey(e)
+	pointer := operationPointer(e.method, e.path)
+	op := e.operation
+
+	if e.summary == "" && e.description == "" {
+		l.report(LintOperationDescription, pointer, key, "%s has no summary or description", key)
+	}
+	if op == nil {
+		return
+	}
+
+	for i, param := range op.Parameters {
+		if param == nil || isExcludedAudience(l.opts.ConvertOptions, param.Extensions) {
+			continue
+		}
+		if param.Description == "" {
+			l.report(LintParameterDescription, pointer+"/parameters/"+strconv.Itoa(i), key,
+				"%s parameter %q has no description", param.In, param.Name)
+		}
+	}
+
+	hasErrorResponse := false
+	if op.Responses != nil {
+		hasErrorResponse = op.Responses.Default != nil
+		if op.Responses.Codes != nil {
+			for pair := op.Responses.Codes.First(); pair != nil; pair = pair.Next() {
+				code, resp := pair.Key(), pair.Value()
+				if strings.HasPrefix(code, "4") || strings.HasPrefix(code, "5") {
+					hasErrorResponse = true
+				}
+				if resp == nil || isExcludedAudience(l.opts.ConvertOptions, resp.Extensions) || resp.Content == nil {
+					continue
+				}
+				mt := resp.Content.GetOrZero("application/json")
+				if mt != nil && !hasExample(mt) {
+					l.report(LintResponseExample, pointer+"/responses/"+escapePointer(code)+"/content/application~1json", key,
+						"%s response has no example", code)
+				}
+			}
+		}
+	}
+	if !hasErrorResponse {
+		l.report(LintErrorResponse, pointer+"/responses", key, "%s documents no error responses", key)
+	}
+}
+
+// lintSchema checks the properties of a schema and of its inline subschemas
+func (l *linter) lintSchema(schema *base.Schema, pointer string) {
+	if schema.Properties != nil {
+		for pair := schema.Properties.First(); pair != nil; pair = pair.Next() {
+			name, proxy := pair.Key(), pair.Value()
+			// Referenced schemas are checked on their own
+			if proxy == nil || proxy.IsReference() {
+				continue
+			}
+			prop := proxy.Schema()
+			if prop == nil || isExcludedAudience(l.opts.ConvertOptions, prop.Extensions) {
+				continue
+			}
+
+			propPointer := pointer + "/properties/" + escapePointer(name)
+			switch {
+			case len(prop.Enum) > 0:
+				if prop.Description == "" {
+					l.report(LintEnumDescription, propPointer, "", "enum field %q has no description", name)
+				}
+			case prop.Description == "" && !isObjectProperty(prop):
+				l.report(LintFieldDescription, propPointer, "", "field %q has no description", name)
+			}
+
+			l.lintSchema(prop, propPointer)
+			if prop.Items != nil && prop.Items.IsA() && !prop.Items.A.IsReference() {
+				if items := prop.Items.A.Schema(); items != nil {
+					l.lintSchema(items, propPointer+"/items")
+				}
+			}
+		}
+	}
+
+	for _, composition := range []struct {
+		keyword string
+		proxies []*base.SchemaProxy
+	}{{"allOf", schema.AllOf}, {"oneOf", schema.OneOf}, {"anyOf", schema.AnyOf}} {
+		for i, proxy := range composition.proxies {
+			if proxy == nil || proxy.IsReference() {
+				continue
+			}
+			if sub := proxy.Schema(); sub != nil {
+				l.lintSchema(sub, pointer+"/"+composition.keyword+"/"+strconv.Itoa(i))
+			}
+		}
+	}
+}
+
+// isObjectProperty reports whether a property holds an object or array of objects, which are
+// described by their own fields rather than a description
+func isObjectProperty(schema *base.Schema) bool {
+	if slices.Contains(schema.Type, "object") {
+		return true
+	}
+	if slices.Contains(schema.Type, "array") && schema.Items != nil && schema.Items.IsA() {
+		items := schema.Items.A
+		if items.IsReference() {
+			return true
+		}
+		if s := items.Schema(); s != nil && slices.Contains(s.Type, "object") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasExample reports whether a media type or its schema declares an example
+func hasExample(mt *v3.MediaType) bool {
+	if mt.Example != nil || (mt.Examples != nil && mt.Examples.Len() > 0) {
+		return true
+	}
+	if mt.Schema == nil {
+		return false
+	}
+	schema := mt.Schema.Schema()
+	return schema != nil && (schema.Example != nil || len(schema.Examples) > 0)
+}
+
+// unusedSchemas returns the component schemas that are not reachable from any reference outside
+// components/schemas, in document order
+func unusedSchemas(openapi []byte) []string {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(openapi, &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+	root := doc.Content[0]
+	schemas := mappingValue(mappingValue(root, "components"), "schemas")
+	if schemas == nil {
+		return nil
+	}
+
+	// References made by each schema, and by everything outside components/schemas
+	refs := make(map[string][]string)
+	var roots []string
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "components" {
+			roots = append(roots, schemaRefs(root.Content[i+1])...)
+			continue
+		}
+		components := root.Content[i+1]
+		for j := 0; j+1 < len(components.Content); j += 2 {
+			if components.Content[j].Value != "schemas" {
+				roots = append(roots, schemaRefs(components.Content[j+1])...)
+			}
+		}
+	}
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		refs[schemas.Content[i].Value] = schemaRefs(schemas.Content[i+1])
+	}
+
+	used := make(map[string]bool)
+	for len(roots) > 0 {
+		name := roots[len(roots)-1]
+		roots = roots[:len(roots)-1]
+		if used[name] {
+			continue
+		}
+		used[name] = true
+		roots = append(roots, refs[name]...)
+	}
+
+	var unused []string
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		if name := schemas.Content[i].Value; !used[name] {
+			unused = append(unused, name)
+		}
+	}
+	return unused
+}
+
+// schemaRefs returns the names of the component schemas referenced within node
+func schemaRefs(node *yaml.Node) []string {
+	var names []string
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "$ref" && value.Kind == yaml.ScalarNode && strings.HasPrefix(value.Value, schemaRefPrefix) {
+				names = append(names, strings.TrimPrefix(value.Value, schemaRefPrefix))
+				continue
+			}
+			names = append(names, schemaRefs(value)...)
+		}
+		return names
+	}
+	for _, child := range node.Content {
+		names = append(names, schemaRefs(child)...)
+	}
+	return names
+}
@@ -0,0 +1,227 @@
+package conv_test
+
+import (
+	"testing"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lintSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: fields
+          in: query
+          description: Fields to return
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    delete:
+      summary: Delete pet
+      responses:
+        '204':
+          description: Deleted
+        '404':
+          description: Not found
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+              example:
+                message: pet not found
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          description: Name of the pet
+        color:
+          type: string
+        status:
+          type: string
+          enum: [available, sold]
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object
+      properties:
+        address:
+          type: object
+          properties:
+            city:
+              type: string
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+          description: Error message
+    Legacy:
+      type: object
+      properties:
+        id:
+          type: string
+          description: Legacy identifier`
+
+func TestLint(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		opts         conv.LintOptions
+		wantFindings []conv.LintFinding
+		wantErr      string
+	}{
+		{
+			name: "default rules",
+			wantFindings: []conv.LintFinding{
+				{
+					Rule:      conv.LintOperationDescription,
+					Severity:  conv.SeverityWarning,
+					Message:   "GET /pets/{id} has no summary or description",
+					Pointer:   "/paths/~1pets~1{id}/get",
+					Operation: "GET /pets/{id}",
+				},
+				{
+					Rule:      conv.LintParameterDescription,
+					Severity:  conv.SeverityWarning,
+					Message:   `path parameter "id" has no description`,
+					Pointer:   "/paths/~1pets~1{id}/get/parameters/0",
+					Operation: "GET /pets/{id}",
+				},
+				{
+					Rule:      conv.LintResponseExample,
+					Severity:  conv.SeverityInfo,
+					Message:   "200 response has no example",
+					Pointer:   "/paths/~1pets~1{id}/get/responses/200/content/application~1json",
+					Operation: "GET /pets/{id}",
+				},
+				{
+					Rule:      conv.LintErrorResponse,
+					Severity:  conv.SeverityWarning,
+					Message:   "GET /pets/{id} documents no error responses",
+					Pointer:   "/paths/~1pets~1{id}/get/responses",
+					Operation: "GET /pets/{id}",
+				},
+				{
+					Rule:     conv.LintFieldDescription,
+					Severity: conv.SeverityWarning,
+					Message:  `field "color" has no description`,
+					Pointer:  "/components/schemas/Pet/properties/color",
+				},
+				{
+					Rule:     conv.LintEnumDescription,
+					Severity: conv.SeverityWarning,
+					Message:  `enum field "status" has no description`,
+					Pointer:  "/components/schemas/Pet/properties/status",
+				},
+				{
+					Rule:     conv.LintFieldDescription,
+					Severity: conv.SeverityWarning,
+					Message:  `field "city" has no description`,
+					Pointer:  "/components/schemas/Owner/properties/address/properties/city",
+				},
+				{
+					Rule:     conv.LintUnusedSchema,
+					Severity: conv.SeverityWarning,
+					Message:  `schema "Legacy" is not referenced`,
+					Pointer:  "/components/schemas/Legacy",
+				},
+			},
+		},
+		{
+			name: "rule severities",
+			opts: conv.LintOptions{
+				ConvertOptions: conv.ConvertOptions{Include: conv.OperationFilter{Methods: []string{"get"}}},
+				Rules: map[conv.LintRule]conv.Severity{
+					conv.LintOperationDescription: conv.SeverityError,
+					conv.LintParameterDescription: conv.SeverityOff,
+					conv.LintResponseExample:      conv.SeverityOff,
+					conv.LintErrorResponse:        conv.SeverityOff,
+					conv.LintFieldDescription:     conv.SeverityOff,
+					conv.LintEnumDescription:      conv.SeverityInfo,
+					conv.LintUnusedSchema:         conv.SeverityOff,
+				},
+			},
+			wantFindings: []conv.LintFinding{
+				{
+					Rule:      conv.LintOperationDescription,
+					Severity:  conv.SeverityError,
+					Message:   "GET /pets/{id} has no summary or description",
+					Pointer:   "/paths/~1pets~1{id}/get",
+					Operation: "GET /pets/{id}",
+				},
+				{
+					Rule:     conv.LintEnumDescription,
+					Severity: conv.SeverityInfo,
+					Message:  `enum field "status" has no description`,
+					Pointer:  "/components/schemas/Pet/properties/status",
+				},
+			},
+		},
+		{
+			name:    "unknown rule",
+			opts:    conv.LintOptions{Rules: map[conv.LintRule]conv.Severity{"spelling": conv.SeverityError}},
+			wantErr: "unknown lint rule: spelling",
+		},
+		{
+			name:    "unsupported severity",
+			opts:    conv.LintOptions{Rules: map[conv.LintRule]conv.Severity{conv.LintUnusedSchema: "fatal"}},
+			wantErr: "unsupported severity for unused-schema: fatal",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Lint([]byte(lintSpec), test.opts)
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.wantFindings, result.Findings)
+		})
+	}
+}
+
+func TestLintEscapesResponsePointers(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      summary: List pets
+      responses:
+        '2/0~0':
+          description: Success
+          content:
+            application/json:
+              schema:
+                type: object
+        '400':
+          description: Invalid request`
+
+	result, err := conv.Lint([]byte(spec), conv.LintOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, result.Findings, 1)
+	assert.Equal(t, conv.LintResponseExample, result.Findings[0].Rule)
+	assert.Equal(t, "/paths/~1pets/get/responses/2~10~00/content/application~1json", result.Findings[0].Pointer)
+}
@@ -0,0 +1,750 @@
+package conv
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"go.yaml.in/yaml/v4"
+)
+
+// CodeSampleLanguage selects a language for the request samples generated for each operation
+type CodeSampleLanguage string
+
+const (
+	// CodeSampleCurl renders a curl command (default)
+	CodeSampleCurl CodeSampleLanguage = "curl"
+	// CodeSampleGo renders Go using net/http
+	CodeSampleGo CodeSampleLanguage = "go"
+	// CodeSamplePython renders Python using requests
+	CodeSamplePython CodeSampleLanguage = "python"
+	// CodeSampleJavaScript renders JavaScript using fetch
+	CodeSampleJavaScript CodeSampleLanguage = "javascript"
+	// CodeSampleHTTPie renders an HTTPie command
+	CodeSampleHTTPie CodeSampleLanguage = "httpie"
+)
+
+// codeSampleGenerators renders a request in each supported language, with the heading label and
+// code block language of its samples
+var codeSampleGenerators = map[CodeSampleLanguage]struct {
+	label  string
+	fence  string
+	render func(req sampleRequest) string
+}{
+	CodeSampleCurl:       {label: "curl", fence: "bash", render: curlSample},
+	CodeSampleGo:         {label: "Go", fence: "go", render: goSample},
+	CodeSamplePython:     {label: "Python", fence: "python", render: pythonSample},
+	CodeSampleJavaScript: {label: "JavaScript", fence: "javascript", render: javaScriptSample},
+	CodeSampleHTTPie:     {label: "HTTPie", fence: "bash", render: httpieSample},
+}
+
+// codeSampleExtensions are the vendor extensions holding hand-written code samples
+var codeSampleExtensions = []string{"x-codeSamples", "x-code-samples"}
+
+// defaultServerURL is the base URL of code samples for specs without servers, and the host
+// relative server URLs are resolved against
+const defaultServerURL = "http://localhost"
+
+// sampleRequest is a call to an operation filled in with example values, rendered as code samples
+type sampleRequest struct {
+	method  string
+	url     string
+	headers []sampleHeader
+	// body is the JSON request example, empty when the operation has no JSON request body
+	body string
+	// formType is the media type form is sent as, when the operation has a form request body
+	// instead of a JSON one
+	formType string
+	form     []sampleFormField
+}
+
+type sampleHeader struct {
+	name  string
+	value string
+}
+
+// sampleFormField is a field of a form request body. The value of a file field is a path placeholder.
+type sampleFormField struct {
+	name  string
+	value string
+	file  bool
+}
+
+// codeSample is a request sample ready to be rendered as a code block
+type codeSample struct {
+	label  string
+	fence  string
+	source string
+}
+
+// renderCodeSamples renders the request samples of the operation, titled by language when
+// there are several
+func (r *renderer) renderCodeSamples(builder *strings.Builder, e endpoint) error {
+	if e.operation == nil {
+		return nil
+	}
+
+	samples, err := r.codeSamples(e)
+	if err != nil || len(samples) == 0 {
+		return err
+	}
+
+	builder.WriteString(r.heading(3) + "Example Request\n\n")
+	for _, sample := range samples {
+		if len(samples) > 1 {
+			builder.WriteString(r.heading(4) + sample.label + "\n\n")
+		}
+		builder.WriteString("```" + sample.fence + "\n")
+		builder.WriteString(strings.TrimSuffix(sample.source, "\n"))
+		builder.WriteString("\n```\n\n")
+	}
+	return nil
+}
+
+// codeSamples returns the hand-written samples of the operation when it has any, otherwise a
+// sample generated in each configured language
+func (r *renderer) codeSamples(e endpoint) ([]codeSample, error) {
+	if samples := r.extensionCodeSamples(e.operation); len(samples) > 0 {
+		return samples, nil
+	}
+	if r.opts.DisableCodeSamples {
+		return nil, nil
+	}
+
+	req, err := r.sampleRequest(e)
+	if err != nil {
+		return nil, err
+	}
+
+	languages := r.opts.CodeSampleLanguages
+	if len(languages) == 0 {
+		languages = []CodeSampleLanguage{CodeSampleCurl}
+	}
+
+	samples := make([]codeSample, 0, len(languages))
+	for _, lang := range languages {
+		gen := codeSampleGenerators[lang]
+		samples = append(samples, codeSample{label: gen.label, fence: gen.fence, source: gen.render(req)})
+	}
+	return samples, nil
+}
+
+// extensionCodeSamples returns the samples of the x-codeSamples (or x-code-samples) extension
+// of an operation, a list of objects with lang, label and source
+func (r *renderer) extensionCodeSamples(op *v3.Operation) []codeSample {
+	if op.Extensions == nil {
+		return nil
+	}
+
+	for _, key := range codeSampleExtensions {
+		node := op.Extensions.GetOrZero(key)
+		if node == nil {
+			continue
+		}
+
+		var entries []struct {
+			Lang   string `yaml:"lang"`
+			Label  string `yaml:"label"`
+			Source string `yaml:"source"`
+		}
+		if err := node.Decode(&entries); err != nil {
+			r.warn(WarningInvalidCodeSamples, r.pointer+"/"+key, "%s is not a list of code samples with lang, label and source", key)
+			return nil
+		}
+
+		var samples []codeSample
+		for _, entry := range entries {
+			if entry.Source == "" {
+				continue
+			}
+			label := entry.Label
+			if label == "" {
+				label = entry.Lang
+			}
+			fence := strings.ToLower(strings.ReplaceAll(entry.Lang, " ", ""))
+			samples = append(samples, codeSample{label: label, fence: fence, source: entry.Source})
+		}
+		return samples
+	}
+
+	return nil
+}
+
+// sampleRequest builds a request for the operation from the first server URL, parameter
+// examples, the security requirements and the first JSON request example
+func (r *renderer) sampleRequest(e endpoint) (sampleRequest, error) {
+	op := e.operation
+	req := sampleRequest{method: strings.ToUpper(e.method)}
+
+	path, query := r.samplePathAndQuery(e, false)
+	for _, param := range op.Parameters {
+		if param != nil && param.In == "header" && !isExcludedAudience(r.opts, param.Extensions) {
+			req.headers = append(req.headers, sampleHeader{name: param.Name, value: parameterValue(param)})
+		}
+	}
+
+	for _, scheme := range r.securitySchemes(op) {
+		switch {
+		case scheme.Type == "apiKey" && scheme.In == "query":
+			query = append(query, url.QueryEscape(scheme.Name)+"=<api-key>")
+		case scheme.Type == "apiKey" && scheme.In == "cookie":
+			req.headers = append(req.headers, sampleHeader{name: "Cookie", value: scheme.Name + "=<api-key>"})
+		case scheme.Type == "apiKey":
+			req.headers = append(req.headers, sampleHeader{name: scheme.Name, value: "<api-key>"})
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+			req.headers = append(req.headers, sampleHeader{name: "Authorization", value: "Basic <credentials>"})
+		case scheme.Type == "http" && !strings.EqualFold(scheme.Scheme, "bearer"):
+			req.headers = append(req.headers, sampleHeader{name: "Authorization", value: scheme.Scheme + " <credentials>"})
+		default:
+			// Bearer authentication, OAuth 2 and OpenID Connect all send an access token
+			req.headers = append(req.headers, sampleHeader{name: "Authorization", value: "Bearer <token>"})
+		}
+	}
+
+	req.url = r.joinURL(e, path, query)
+
+	examples, err := r.extractRequestExample(op)
+	if err != nil {
+		return sampleRequest{}, err
+	}
+	if len(examples) > 0 {
+		req.body = examples[0].json
+		req.headers = append(req.headers, sampleHeader{name: "Content-Type", value: "application/json"})
+	} else if op.RequestBody != nil {
+		if formType, schema := formRequestSchema(op.RequestBody); schema != nil {
+			req.formType = formType
+			req.form = r.sampleForm(schema)
+		}
+	}
+
+	return req, nil
+}
+
+// sampleForm returns a field with an example value for every property of a form schema
+func (r *renderer) sampleForm(schema *base.Schema) []sampleFormField {
+	if schema.Properties == nil {
+		return nil
+	}
+
+	var fields []sampleFormField
+	for pair := schema.Properties.First(); pair != nil; pair = pair.Next() {
+		prop := pair.Value().Schema()
+		if prop == nil || r.isHiddenSchema(prop) {
+			continue
+		}
+
+		value, ok := schemaExample(prop)
+		if !ok {
+			value = schemaValue(pair.Key(), prop)
+		}
+		fields = append(fields, sampleFormField{name: pair.Key(), value: value, file: prop.Format == "binary"})
+	}
+	return fields
+}
+
+// sampleURL returns the URL of the operation with its path parameters and its query
+// parameters that are required or have an example filled in
+func (r *renderer) sampleURL(e endpoint) string {
+	path, query := r.samplePathAndQuery(e, true)
+	return r.joinURL(e, path, query)
+}
+
+// samplePathAndQuery returns the operation path with example values for its path parameters,
+// and the encoded required query parameters, plus those with an example when withExamples is set
+func (r *renderer) samplePathAndQuery(e endpoint, withExamples bool) (string, []string) {
+	path := e.path
+	var query []string
+	for _, param := range e.operation.Parameters {
+		if param == nil || isExcludedAudience(r.opts, param.Extensions) {
+			continue
+		}
+		switch param.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+param.Name+"}", escapeSample(parameterValue(param), url.PathEscape))
+		case "query":
+			_, hasExample := parameterExample(param)
+			if (param.Required != nil && *param.Required) || (withExamples && hasExample) {
+				query = append(query, url.QueryEscape(param.Name)+"="+escapeSample(parameterValue(param), url.QueryEscape))
+			}
+		}
+	}
+	return path, query
+}
+
+// joinURL joins the server URL of the operation, path and encoded query parameters
+func (r *renderer) joinURL(e endpoint, path string, query []string) string {
+	u := strings.TrimSuffix(r.serverURL(e), "/") + path
+	if len(query) > 0 {
+		u += "?" + strings.Join(query, "&")
+	}
+	return u
+}
+
+// serverURL returns the first server URL of the operation, its path or the spec, with server
+// variables replaced by their defaults
+func (r *renderer) serverURL(e endpoint) string {
+	servers := e.operation.Servers
+	if len(servers) == 0 && r.model.Paths != nil && r.model.Paths.PathItems != nil {
+		if item := r.model.Paths.PathItems.GetOrZero(e.path); item != nil {
+			servers = item.Servers
+		}
+	}
+	if len(servers) == 0 {
+		servers = r.model.Servers
+	}
+	if len(servers) == 0 || servers[0] == nil || servers[0].URL == "" {
+		return defaultServerURL
+	}
+
+	server := servers[0]
+	serverURL := server.URL
+	if server.Variables != nil {
+		for pair := server.Variables.First(); pair != nil; pair = pair.Next() {
+			if pair.Value() != nil {
+				serverURL = strings.ReplaceAll(serverURL, "{"+pair.Key()+"}", pair.Value().Default)
+			}
+		}
+	}
+	if strings.HasPrefix(serverURL, "/") {
+		serverURL = defaultServerURL + serverURL
+	}
+	return serverURL
+}
+
+// securitySchemes returns the schemes of the first security requirement of the operation,
+// falling back to the spec-wide requirements. An empty operation requirement list disables
+// authentication.
+func (r *renderer) securitySchemes(op *v3.Operation) []*v3.SecurityScheme {
+	requirements := op.Security
+	if requirements == nil {
+		requirements = r.model.Security
+	}
+	if len(requirements) == 0 || requirements[0] == nil || requirements[0].Requirements == nil {
+		return nil
+	}
+	if r.model.Components == nil || r.model.Components.SecuritySchemes == nil {
+		return nil
+	}
+
+	var schemes []*v3.SecurityScheme
+	for pair := requirements[0].Requirements.First(); pair != nil; pair = pair.Next() {
+		if scheme := r.model.Components.SecuritySchemes.GetOrZero(pair.Key()); scheme != nil {
+			schemes = append(schemes, scheme)
+		}
+	}
+	return schemes
+}
+
+// parameterExample returns the explicit example of a parameter, taken from the parameter's
+// example or first named example, or from its schema
+func parameterExample(param *v3.Parameter) (string, bool) {
+	if param.Example != nil {
+		return nodeString(param.Example), true
+	}
+	if param.Examples != nil {
+		for pair := param.Examples.First(); pair != nil; pair = pair.Next() {
+			if example := pair.Value(); example != nil && example.Value != nil {
+				return nodeString(example.Value), true
+			}
+		}
+	}
+	if param.Schema != nil {
+		if schema := param.Schema.Schema(); schema != nil {
+			return schemaExample(schema)
+		}
+	}
+	return "", false
+}
+
+// schemaExample returns the example or first example of a schema
+func schemaExample(schema *base.Schema) (string, bool) {
+	if schema.Example != nil {
+		return nodeString(schema.Example), true
+	}
+	if len(schema.Examples) > 0 && schema.Examples[0] != nil {
+		return nodeString(schema.Examples[0]), true
+	}
+	return "", false
+}
+
+// parameterValue returns a value to send for a parameter: its example, otherwise a value of its
+// schema
+func parameterValue(param *v3.Parameter) string {
+	if example, ok := parameterExample(param); ok {
+		return example
+	}
+
+	var schema *base.Schema
+	if param.Schema != nil {
+		schema = param.Schema.Schema()
+	}
+	return schemaValue(param.Name, schema)
+}
+
+// schemaValue returns a value to send for the named schema without an example: the schema
+// default, the first enum value, a value of the schema type or a "<name>" placeholder
+func schemaValue(name string, schema *base.Schema) string {
+	if schema == nil {
+		return "<" + name + ">"
+	}
+	if schema.Default != nil {
+		return nodeString(schema.Default)
+	}
+	if len(schema.Enum) > 0 && schema.Enum[0] != nil {
+		return nodeString(schema.Enum[0])
+	}
+
+	typ := ""
+	if len(schema.Type) > 0 {
+		typ = schema.Type[0]
+	}
+	switch typ {
+	case "integer", "number":
+		return "1"
+	case "boolean":
+		return "true"
+	}
+	switch schema.Format {
+	case "uuid":
+		return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	case "date":
+		return "2024-01-01"
+	case "date-time":
+		return "2024-01-01T00:00:00Z"
+	}
+	return "<" + name + ">"
+}
+
+// escapeSample escapes a parameter value for a URL, leaving "<name>" placeholders readable
+func escapeSample(value string, escape func(string) string) string {
+	if strings.HasPrefix(value, "<") && strings.HasSuffix(value, ">") {
+		return value
+	}
+	return escape(value)
+}
+
+// nodeString formats an example value for a URL or header: scalars as written in the spec,
+// lists comma-separated and objects as JSON
+func nodeString(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value
+	case yaml.SequenceNode:
+		values := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			values = append(values, nodeString(item))
+		}
+		return strings.Join(values, ",")
+	}
+
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return ""
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+// curlSample renders req as a curl command
+func curlSample(req sampleRequest) string {
+	first := "curl"
+	if req.method != "GET" {
+		first += " -X " + req.method
+	}
+	lines := []string{first + " " + shellQuote(req.url)}
+
+	for _, h := range req.headers {
+		lines = append(lines, "-H "+shellQuote(h.name+": "+h.value))
+	}
+	if req.body != "" {
+		lines = append(lines, "-d "+shellQuote(req.body))
+	}
+	for _, f := range req.form {
+		switch {
+		case req.formType == formURLEncoded:
+			lines = append(lines, "--data-urlencode "+shellQuote(f.name+"="+f.value))
+		case f.file:
+			lines = append(lines, "-F "+shellQuote(f.name+"=@"+f.value))
+		default:
+			// --form-string sends values starting with @ or < as they are instead of reading a file
+			lines = append(lines, "--form-string "+shellQuote(f.name+"="+f.value))
+		}
+	}
+
+	return strings.Join(lines, " \\\n  ")
+}
+
+// goSample renders req as Go using net/http
+func goSample(req sampleRequest) string {
+	var b strings.Builder
+	body := "nil"
+	headers := req.headers
+	switch {
+	case req.body != "":
+		body = "body"
+		quoted := "`" + req.body + "`"
+		if strings.Contains(req.body, "`") {
+			quoted = strconv.Quote(req.body)
+		}
+		b.WriteString("body := strings.NewReader(" + quoted + ")\n")
+	case req.formType == formURLEncoded:
+		body = "strings.NewReader(form.Encode())"
+		b.WriteString("form := url.Values{}\n")
+		for _, f := range req.form {
+			b.WriteString("form.Set(" + strconv.Quote(f.name) + ", " + strconv.Quote(f.value) + ")\n")
+		}
+		b.WriteString("\n")
+		headers = append(headers, sampleHeader{name: "Content-Type", value: formURLEncoded})
+	case req.formType == formMultipart:
+		body = "&body"
+		b.WriteString("var body bytes.Buffer\nform := multipart.NewWriter(&body)\n")
+		declare := ":="
+		for _, f := range req.form {
+			if !f.file {
+				b.WriteString("form.WriteField(" + strconv.Quote(f.name) + ", " + strconv.Quote(f.value) + ")\n")
+				continue
+			}
+			b.WriteString("file, err " + declare + " os.Open(" + strconv.Quote(f.value) + ")\n")
+			b.WriteString("if err != nil {\n\tlog.Fatal(err)\n}\n")
+			b.WriteString("defer file.Close()\n")
+			b.WriteString("part, err " + declare + " form.CreateFormFile(" + strconv.Quote(f.name) + ", filepath.Base(file.Name()))\n")
+			b.WriteString("if err != nil {\n\tlog.Fatal(err)\n}\n")
+			b.WriteString("if _, err := io.Copy(part, file); err != nil {\n\tlog.Fatal(err)\n}\n")
+			declare = "="
+		}
+		b.WriteString("form.Close()\n\n")
+	}
+
+	method := "http.Method" + req.method[:1] + strings.ToLower(req.method[1:])
+	b.WriteString("req, err := http.NewRequest(" + method + ", " + strconv.Quote(req.url) + ", " + body + ")\n")
+	b.WriteString("if err != nil {\n\tlog.Fatal(err)\n}\n")
+	for _, h := range headers {
+		b.WriteString("req.Header.Set(" + strconv.Quote(h.name) + ", " + strconv.Quote(h.value) + ")\n")
+	}
+	if req.formType == formMultipart {
+		b.WriteString("req.Header.Set(\"Content-Type\", form.FormDataContentType())\n")
+	}
+	b.WriteString("\nresp, err := http.DefaultClient.Do(req)\n")
+	b.WriteString("if err != nil {\n\tlog.Fatal(err)\n}\n")
+	b.WriteString("defer resp.Body.Close()\n")
+	b.WriteString("fmt.Println(resp.Status)")
+	return b.String()
+}
+
+// pythonSample renders req as Python using requests
+func pythonSample(req sampleRequest) string {
+	var b strings.Builder
+	b.WriteString("import requests\n\n")
+
+	function := strings.ToLower(req.method)
+	args := []string{strconv.Quote(req.url)}
+	if function == "trace" {
+		function = "request"
+		args = append([]string{strconv.Quote(req.method)}, args...)
+	}
+	if len(req.headers) > 0 {
+		args = append(args, "headers="+headersLiteral(req.headers, "    ", "    ", ": "))
+	}
+	if req.body != "" {
+		args = append(args, "json="+jsonLiteral(req.body, "    ", pythonSyntax))
+	}
+	switch req.formType {
+	case formURLEncoded:
+		args = append(args, "data="+headersLiteral(formPairs(req.form), "    ", "    ", ": "))
+	case formMultipart:
+		// Passing every field as a file makes requests send multipart even without files
+		var b strings.Builder
+		b.WriteString("files={\n")
+		for _, f := range req.form {
+			value := "(None, " + strconv.Quote(f.value) + ")"
+			if f.file {
+				value = "open(" + strconv.Quote(f.value) + ", \"rb\")"
+			}
+			b.WriteString("        " + strconv.Quote(f.name) + ": " + value + ",\n")
+		}
+		b.WriteString("    }")
+		args = append(args, b.String())
+	}
+
+	b.WriteString("response = requests." + function + "(\n")
+	for _, arg := range args {
+		b.WriteString("    " + arg + ",\n")
+	}
+	b.WriteString(")\n")
+	b.WriteString("print(response.status_code, response.text)")
+	return b.String()
+}
+
+// javaScriptSample renders req as JavaScript using fetch
+func javaScriptSample(req sampleRequest) string {
+	var b strings.Builder
+
+	var options []string
+	if req.method != "GET" {
+		options = append(options, "method: "+strconv.Quote(req.method))
+	}
+	if len(req.headers) > 0 {
+		options = append(options, "headers: "+headersLiteral(req.headers, "  ", "  ", ": "))
+	}
+	if req.body != "" {
+		options = append(options, "body: JSON.stringify("+jsonLiteral(req.body, "  ", javaScriptSyntax)+")")
+	}
+	switch req.formType {
+	case formURLEncoded:
+		options = append(options, "body: new URLSearchParams("+headersLiteral(formPairs(req.form), "  ", "  ", ": ")+")")
+	case formMultipart:
+		hasFile := slices.ContainsFunc(req.form, func(f sampleFormField) bool { return f.file })
+		if hasFile {
+			b.WriteString("import { openAsBlob } from \"node:fs\";\n\n")
+		}
+		b.WriteString("const form = new FormData();\n")
+		for _, f := range req.form {
+			if f.file {
+				b.WriteString("form.append(" + strconv.Quote(f.name) + ", await openAsBlob(" + strconv.Quote(f.value) + "), " + strconv.Quote(f.value) + ");\n")
+			} else {
+				b.WriteString("form.append(" + strconv.Quote(f.name) + ", " + strconv.Quote(f.value) + ");\n")
+			}
+		}
+		b.WriteString("\n")
+		options = append(options, "body: form")
+	}
+
+	if len(options) == 0 {
+		b.WriteString("const response = await fetch(" + strconv.Quote(req.url) + ");\n")
+	} else {
+		b.WriteString("const response = await fetch(" + strconv.Quote(req.url) + ", {\n")
+		for _, option := range options {
+			b.WriteString("  " + option + ",\n")
+		}
+		b.WriteString("});\n")
+	}
+	b.WriteString("console.log(response.status, await response.text());")
+	return b.String()
+}
+
+// httpieSample renders req as an HTTPie command
+func httpieSample(req sampleRequest) string {
+	first := "http"
+	switch req.formType {
+	case formURLEncoded:
+		first += " --form"
+	case formMultipart:
+		first += " --multipart"
+	}
+	if req.method != "GET" {
+		first += " " + req.method
+	}
+	lines := []string{first + " " + shellQuote(req.url)}
+
+	for _, h := range req.headers {
+		lines = append(lines, shellQuote(h.name+":"+h.value))
+	}
+	if req.body != "" {
+		lines = append(lines, "--raw "+shellQuote(req.body))
+	}
+	for _, f := range req.form {
+		if f.file {
+			lines = append(lines, shellQuote(f.name+"@"+f.value))
+		} else {
+			lines = append(lines, shellQuote(f.name+"="+f.value))
+		}
+	}
+
+	return strings.Join(lines, " \\\n  ")
+}
+
+// formPairs returns the form fields as name and value pairs for headersLiteral
+func formPairs(fields []sampleFormField) []sampleHeader {
+	pairs := make([]sampleHeader, 0, len(fields))
+	for _, f := range fields {
+		pairs = append(pairs, sampleHeader{name: f.name, value: f.value})
+	}
+	return pairs
+}
+
+// headersLiteral renders headers as a dictionary or object literal, with entries indented by
+// unit past indent
+func headersLiteral(headers []sampleHeader, indent, unit, separator string) string {
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, h := range headers {
+		b.WriteString(indent + unit + strconv.Quote(h.name) + separator + strconv.Quote(h.value) + ",\n")
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// literalSyntax describes how a language writes JSON values as literals
+type literalSyntax struct {
+	indent string
+	null   string
+	true   string
+	false  string
+}
+
+var (
+	pythonSyntax     = literalSyntax{indent: "    ", null: "None", true: "True", false: "False"}
+	javaScriptSyntax = literalSyntax{indent: "  ", null: "null", true: "true", false: "false"}
+)
+
+// jsonLiteral renders a JSON document as a literal in the given syntax, keeping the key order
+func jsonLiteral(body, indent string, syntax literalSyntax) string {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(body), &node); err != nil || len(node.Content) == 0 {
+		return strconv.Quote(body)
+	}
+	return nodeLiteral(node.Content[0], indent, syntax)
+}
+
+func nodeLiteral(node *yaml.Node, indent string, syntax literalSyntax) string {
+	inner := indent + syntax.indent
+	switch node.Kind {
+	case yaml.MappingNode:
+		if len(node.Content) == 0 {
+			return "{}"
+		}
+		var b strings.Builder
+		b.WriteString("{\n")
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			b.WriteString(inner + strconv.Quote(node.Content[i].Value) + ": " + nodeLiteral(node.Content[i+1], inner, syntax) + ",\n")
+		}
+		b.WriteString(indent + "}")
+		return b.String()
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			return "[]"
+		}
+		var b strings.Builder
+		b.WriteString("[\n")
+		for _, item := range node.Content {
+			b.WriteString(inner + nodeLiteral(item, inner, syntax) + ",\n")
+		}
+		b.WriteString(indent + "]")
+		return b.String()
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return syntax.null
+	case "!!bool":
+		if node.Value == "true" {
+			return syntax.true
+		}
+		return syntax.false
+	case "!!int", "!!float":
+		return node.Value
+	}
+	return strconv.Quote(node.Value)
+}
+
+// shellQuote quotes s as a single POSIX shell word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
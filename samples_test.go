@@ -0,0 +1,540 @@
+package conv_test
+
+import (
+	"testing"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertCodeSamples(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com/v1
+    variables:
+      region:
+        default: eu
+security:
+  - bearerAuth: []
+paths:
+  /pets/{id}:
+    get:
+      summary: Get pet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          example: pet 123
+          schema:
+            type: string
+        - name: fields
+          in: query
+          required: true
+          schema:
+            type: array
+            items:
+              type: string
+            example: [name, tag]
+        - name: page
+          in: query
+          schema:
+            type: integer
+        - name: X-Request-ID
+          in: header
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Success
+  /pets:
+    post:
+      summary: Create pet
+      security:
+        - apiKey: []
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+            example:
+              name: O'Malley
+      responses:
+        '201':
+          description: Created
+  /health:
+    get:
+      summary: Health check
+      servers:
+        - url: /internal
+      security: []
+      responses:
+        '200':
+          description: Healthy
+components:
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer
+    apiKey:
+      type: apiKey
+      in: query
+      name: api_key
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          description: Name of the pet`
+
+	for _, test := range []struct {
+		name      string
+		opts      conv.ConvertOptions
+		wantMd    []string
+		notWantMd []string
+	}{
+		{
+			name: "curl samples",
+			opts: conv.ConvertOptions{Title: "Test API"},
+			wantMd: []string{
+				"### Example Request\n\n```bash\n" +
+					"curl 'https://eu.example.com/v1/pets/pet%20123?fields=name%2Ctag' \\\n" +
+					"  -H 'X-Request-ID: <X-Request-ID>' \\\n" +
+					"  -H 'Authorization: Bearer <token>'\n" +
+					"```\n\n",
+				"### Example Request\n\n```bash\n" +
+					"curl -X POST 'https://eu.example.com/v1/pets?api_key=<api-key>' \\\n" +
+					"  -H 'Content-Type: application/json' \\\n" +
+					"  -d '{\n" +
+					"   \"name\": \"O'\\''Malley\"\n" +
+					"}'\n" +
+					"```\n\n",
+				"### Example Request\n\n```bash\n" +
+					"curl 'http://localhost/internal/health'\n" +
+					"```\n\n",
+			},
+			notWantMd: []string{"page="},
+		},
+		{
+			name:      "disabled",
+			opts:      conv.ConvertOptions{Title: "Test API", DisableCodeSamples: true},
+			notWantMd: []string{"Example Request", "curl"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(spec), test.opts)
+			require.NoError(t, err)
+			md := string(result.Markdown)
+
+			for _, want := range test.wantMd {
+				assert.Contains(t, md, want)
+			}
+			for _, notWant := range test.notWantMd {
+				assert.NotContains(t, md, notWant)
+			}
+		})
+	}
+}
+
+func TestConvertCodeSampleLanguages(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+servers:
+  - url: https://api.example.com
+paths:
+  /pets:
+    get:
+      summary: List pets
+      responses:
+        '200':
+          description: Success
+    post:
+      summary: Create pet
+      parameters:
+        - name: X-Request-ID
+          in: header
+          example: abc
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+            example:
+              name: Fluffy
+              vaccinated: true
+              owner: null
+              tags: [cat]
+      responses:
+        '201':
+          description: Created
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          description: Name of the pet`
+
+	for _, test := range []struct {
+		name      string
+		languages []conv.CodeSampleLanguage
+		wantMd    []string
+		notWantMd []string
+		wantErr   string
+	}{
+		{
+			name:      "go",
+			languages: []conv.CodeSampleLanguage{conv.CodeSampleGo},
+			wantMd: []string{
+				"### Example Request\n\n```go\n" +
+					"req, err := http.NewRequest(http.MethodGet, \"https://api.example.com/pets\", nil)\n" +
+					"if err != nil {\n\tlog.Fatal(err)\n}\n\n" +
+					"resp, err := http.DefaultClient.Do(req)\n",
+				"```go\n" +
+					"body := strings.NewReader(`{\n" +
+					"   \"name\": \"Fluffy\",\n" +
+					"   \"owner\": null,\n" +
+					"   \"tags\": [\n" +
+					"      \"cat\"\n" +
+					"   ],\n" +
+					"   \"vaccinated\": true\n" +
+					"}`)\n" +
+					"req, err := http.NewRequest(http.MethodPost, \"https://api.example.com/pets\", body)\n" +
+					"if err != nil {\n\tlog.Fatal(err)\n}\n" +
+					"req.Header.Set(\"X-Request-ID\", \"abc\")\n" +
+					"req.Header.Set(\"Content-Type\", \"application/json\")\n",
+			},
+			notWantMd: []string{"#### Go", "curl"},
+		},
+		{
+			name:      "python",
+			languages: []conv.CodeSampleLanguage{conv.CodeSamplePython},
+			wantMd: []string{
+				"```python\n" +
+					"import requests\n\n" +
+					"response = requests.post(\n" +
+					"    \"https://api.example.com/pets\",\n" +
+					"    headers={\n" +
+					"        \"X-Request-ID\": \"abc\",\n" +
+					"        \"Content-Type\": \"application/json\",\n" +
+					"    },\n" +
+					"    json={\n" +
+					"        \"name\": \"Fluffy\",\n" +
+					"        \"owner\": None,\n" +
+					"        \"tags\": [\n" +
+					"            \"cat\",\n" +
+					"        ],\n" +
+					"        \"vaccinated\": True,\n" +
+					"    },\n" +
+					")\n" +
+					"print(response.status_code, response.text)\n```",
+			},
+		},
+		{
+			name:      "javascript",
+			languages: []conv.CodeSampleLanguage{conv.CodeSampleJavaScript},
+			wantMd: []string{
+				"```javascript\n" +
+					"const response = await fetch(\"https://api.example.com/pets\");\n" +
+					"console.log(response.status, await response.text());\n```",
+				"```javascript\n" +
+					"const response = await fetch(\"https://api.example.com/pets\", {\n" +
+					"  method: \"POST\",\n" +
+					"  headers: {\n" +
+					"    \"X-Request-ID\": \"abc\",\n" +
+					"    \"Content-Type\": \"application/json\",\n" +
+					"  },\n" +
+					"  body: JSON.stringify({\n" +
+					"    \"name\": \"Fluffy\",\n" +
+					"    \"owner\": null,\n" +
+					"    \"tags\": [\n" +
+					"      \"cat\",\n" +
+					"    ],\n" +
+					"    \"vaccinated\": true,\n" +
+					"  }),\n" +
+					"});\n",
+			},
+		},
+		{
+			name:      "several languages are titled",
+			languages: []conv.CodeSampleLanguage{conv.CodeSampleCurl, conv.CodeSampleHTTPie},
+			wantMd: []string{
+				"### Example Request\n\n#### curl\n\n```bash\ncurl 'https://api.example.com/pets'\n```\n\n" +
+					"#### HTTPie\n\n```bash\nhttp 'https://api.example.com/pets'\n```\n\n",
+				"```bash\n" +
+					"http POST 'https://api.example.com/pets' \\\n" +
+					"  'X-Request-ID:abc' \\\n" +
+					"  'Content-Type:application/json' \\\n" +
+					"  --raw '{\n",
+			},
+		},
+		{
+			name:      "unsupported language",
+			languages: []conv.CodeSampleLanguage{"ruby"},
+			wantErr:   "unsupported code sample language: ruby",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(spec), conv.ConvertOptions{
+				Title:               "Test API",
+				CodeSampleLanguages: test.languages,
+			})
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			md := string(result.Markdown)
+
+			for _, want := range test.wantMd {
+				assert.Contains(t, md, want)
+			}
+			for _, notWant := range test.notWantMd {
+				assert.NotContains(t, md, notWant)
+			}
+		})
+	}
+}
+
+func TestConvertFormCodeSamples(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+servers:
+  - url: https://api.example.com
+paths:
+  /login:
+    post:
+      summary: Log in
+      requestBody:
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              properties:
+                username:
+                  type: string
+                  example: alice
+                remember:
+                  type: boolean
+      responses:
+        '204':
+          description: Logged in
+  /photos:
+    post:
+      summary: Upload photo
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                file:
+                  type: string
+                  format: binary
+                caption:
+                  type: string
+      responses:
+        '204':
+          description: Uploaded`
+
+	for _, test := range []struct {
+		name     string
+		language conv.CodeSampleLanguage
+		wantMd   []string
+	}{
+		{
+			name:     "curl",
+			language: conv.CodeSampleCurl,
+			wantMd: []string{
+				"curl -X POST 'https://api.example.com/login' \\\n" +
+					"  --data-urlencode 'username=alice' \\\n" +
+					"  --data-urlencode 'remember=true'\n```",
+				"curl -X POST 'https://api.example.com/photos' \\\n" +
+					"  -F 'file=@<file>' \\\n" +
+					"  --form-string 'caption=<caption>'\n```",
+			},
+		},
+		{
+			name:     "go",
+			language: conv.CodeSampleGo,
+			wantMd: []string{
+				"```go\n" +
+					"form := url.Values{}\n" +
+					"form.Set(\"username\", \"alice\")\n" +
+					"form.Set(\"remember\", \"true\")\n\n" +
+					"req, err := http.NewRequest(http.MethodPost, \"https://api.example.com/login\", strings.NewReader(form.Encode()))\n" +
+					"if err != nil {\n\tlog.Fatal(err)\n}\n" +
+					"req.Header.Set(\"Content-Type\", \"application/x-www-form-urlencoded\")\n",
+				"```go\n" +
+					"var body bytes.Buffer\n" +
+					"form := multipart.NewWriter(&body)\n" +
+					"file, err := os.Open(\"<file>\")\n" +
+					"if err != nil {\n\tlog.Fatal(err)\n}\n" +
+					"defer file.Close()\n" +
+					"part, err := form.CreateFormFile(\"file\", filepath.Base(file.Name()))\n" +
+					"if err != nil {\n\tlog.Fatal(err)\n}\n" +
+					"if _, err := io.Copy(part, file); err != nil {\n\tlog.Fatal(err)\n}\n" +
+					"form.WriteField(\"caption\", \"<caption>\")\n" +
+					"form.Close()\n\n" +
+					"req, err := http.NewRequest(http.MethodPost, \"https://api.example.com/photos\", &body)\n" +
+					"if err != nil {\n\tlog.Fatal(err)\n}\n" +
+					"req.Header.Set(\"Content-Type\", form.FormDataContentType())\n",
+			},
+		},
+		{
+			name:     "python",
+			language: conv.CodeSamplePython,
+			wantMd: []string{
+				"response = requests.post(\n" +
+					"    \"https://api.example.com/login\",\n" +
+					"    data={\n" +
+					"        \"username\": \"alice\",\n" +
+					"        \"remember\": \"true\",\n" +
+					"    },\n" +
+					")\n",
+				"response = requests.post(\n" +
+					"    \"https://api.example.com/photos\",\n" +
+					"    files={\n" +
+					"        \"file\": open(\"<file>\", \"rb\"),\n" +
+					"        \"caption\": (None, \"<caption>\"),\n" +
+					"    },\n" +
+					")\n",
+			},
+		},
+		{
+			name:     "javascript",
+			language: conv.CodeSampleJavaScript,
+			wantMd: []string{
+				"const response = await fetch(\"https://api.example.com/login\", {\n" +
+					"  method: \"POST\",\n" +
+					"  body: new URLSearchParams({\n" +
+					"    \"username\": \"alice\",\n" +
+					"    \"remember\": \"true\",\n" +
+					"  }),\n" +
+					"});\n",
+				"```javascript\n" +
+					"import { openAsBlob } from \"node:fs\";\n\n" +
+					"const form = new FormData();\n" +
+					"form.append(\"file\", await openAsBlob(\"<file>\"), \"<file>\");\n" +
+					"form.append(\"caption\", \"<caption>\");\n\n" +
+					"const response = await fetch(\"https://api.example.com/photos\", {\n" +
+					"  method: \"POST\",\n" +
+					"  body: form,\n" +
+					"});\n",
+			},
+		},
+		{
+			name:     "httpie",
+			language: conv.CodeSampleHTTPie,
+			wantMd: []string{
+				"http --form POST 'https://api.example.com/login' \\\n" +
+					"  'username=alice' \\\n" +
+					"  'remember=true'\n```",
+				"http --multipart POST 'https://api.example.com/photos' \\\n" +
+					"  'file@<file>' \\\n" +
+					"  'caption=<caption>'\n```",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(spec), conv.ConvertOptions{
+				Title:               "Test API",
+				CodeSampleLanguages: []conv.CodeSampleLanguage{test.language},
+			})
+			require.NoError(t, err)
+
+			md := string(result.Markdown)
+			for _, want := range test.wantMd {
+				assert.Contains(t, md, want)
+			}
+		})
+	}
+}
+
+func TestConvertCodeSampleExtensions(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		extension    string
+		opts         conv.ConvertOptions
+		wantMd       []string
+		notWantMd    []string
+		wantWarnings []string
+	}{
+		{
+			name: "x-codeSamples replace generated samples",
+			extension: `      x-codeSamples:
+        - lang: Go
+          label: Go SDK
+          source: |
+            pets, err := client.ListPets(ctx)
+        - lang: Shell
+          source: petctl list`,
+			opts: conv.ConvertOptions{Title: "Test API"},
+			wantMd: []string{
+				"### Example Request\n\n" +
+					"#### Go SDK\n\n```go\npets, err := client.ListPets(ctx)\n```\n\n" +
+					"#### Shell\n\n```shell\npetctl list\n```\n\n",
+			},
+			notWantMd: []string{"curl"},
+		},
+		{
+			name: "x-code-samples kept when generation is disabled",
+			extension: `      x-code-samples:
+        - lang: Shell
+          source: petctl list`,
+			opts:   conv.ConvertOptions{Title: "Test API", DisableCodeSamples: true},
+			wantMd: []string{"### Example Request\n\n```shell\npetctl list\n```\n\n"},
+		},
+		{
+			name:         "invalid extension",
+			extension:    `      x-codeSamples: petctl list`,
+			opts:         conv.ConvertOptions{Title: "Test API"},
+			wantMd:       []string{"```bash\ncurl 'http://localhost/pets'\n```"},
+			wantWarnings: []string{"/paths/~1pets/get/x-codeSamples: x-codeSamples is not a list of code samples with lang, label and source"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			spec := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      summary: List pets
+` + test.extension + `
+      responses:
+        '200':
+          description: Success`
+
+			result, err := conv.Convert([]byte(spec), test.opts)
+			require.NoError(t, err)
+			md := string(result.Markdown)
+
+			for _, want := range test.wantMd {
+				assert.Contains(t, md, want)
+			}
+			for _, notWant := range test.notWantMd {
+				assert.NotContains(t, md, notWant)
+			}
+
+			var warnings []string
+			for _, w := range result.Warnings {
+				warnings = append(warnings, w.String())
+			}
+			assert.Equal(t, test.wantWarnings, warnings)
+		})
+	}
+}
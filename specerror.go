@@ -0,0 +1,94 @@
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// SpecError is a conversion error located in the spec, so authors can jump straight to the
+// problem
+type SpecError struct {
+	// Operation is the operation being converted (e.g. "GET /pets"), empty outside operations
+	Operation string
+	// StatusCode is the response status code, empty outside responses
+	StatusCode string
+	// MediaType is the media type of the request body or response, when the error is in one
+	MediaType string
+	// Pointer is a JSON pointer to the offending part of the spec
+	Pointer string
+	// Line and Column locate the offending part in the YAML source, 0 when unknown. Swagger 2.0
+	// specs and specs with external references are rewritten before parsing, so their errors
+	// carry no line.
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *SpecError) Error() string {
+	var context []string
+	if e.Operation != "" {
+		context = append(context, e.Operation)
+	}
+	switch {
+	case e.StatusCode != "":
+		context = append(context, e.StatusCode+" response")
+	case e.MediaType != "":
+		context = append(context, "request body")
+	}
+	if e.MediaType != "" {
+		context = append(context, e.MediaType)
+	}
+
+	var location []string
+	if e.Pointer != "" {
+		location = append(location, "at "+e.Pointer)
+	}
+	if e.Line > 0 {
+		location = append(location, fmt.Sprintf("line %d, column %d", e.Line, e.Column))
+	}
+
+	msg := e.Err.Error()
+	if len(context) > 0 {
+		msg = strings.Join(context, " ") + ": " + msg
+	}
+	if len(location) > 0 {
+		msg += " (" + strings.Join(location, ", ") + ")"
+	}
+	return msg
+}
+
+func (e *SpecError) Unwrap() error {
+	return e.Err
+}
+
+// specError creates an error located at node, which may be nil
+func (r *renderer) specError(node *yaml.Node, err error) *SpecError {
+	e := &SpecError{Err: err}
+	if node != nil && !r.rewritten {
+		e.Line, e.Column = node.Line, node.Column
+	}
+	return e
+}
+
+// locate adds context to an error as it propagates out of the spec: pointer is prepended to the
+// error's pointer and update fills in the fields known at this level. Errors that are not yet a
+// SpecError are wrapped in one.
+func locate(err error, pointer string, update func(e *SpecError)) error {
+	if err == nil {
+		return nil
+	}
+
+	var e *SpecError
+	if !errors.As(err, &e) {
+		e = &SpecError{Err: err}
+		err = e
+	}
+	e.Pointer = pointer + e.Pointer
+	if update != nil {
+		update(e)
+	}
+	return err
+}
@@ -0,0 +1,117 @@
+package conv_test
+
+import (
+	"errors"
+	"testing"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertSpecErrorLocation(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		openapi string
+		wantErr string
+		want    conv.SpecError
+	}{
+		{
+			name: "inline response schema",
+			openapi: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      summary: List users
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                type: object`,
+			wantErr: "GET /users 200 response application/json: inline schemas not supported, use $ref " +
+				"(at /paths/~1users/get/responses/200/content/application~1json/schema, line 15, column 17)",
+			want: conv.SpecError{
+				Operation:  "GET /users",
+				StatusCode: "200",
+				MediaType:  "application/json",
+				Pointer:    "/paths/~1users/get/responses/200/content/application~1json/schema",
+				Line:       15,
+				Column:     17,
+			},
+		},
+		{
+			name: "inline request schema",
+			openapi: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      summary: Create user
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '204':
+          description: Created`,
+			wantErr: "POST /users request body application/json: inline schemas not supported, use $ref " +
+				"(at /paths/~1users/post/requestBody/content/application~1json/schema, line 13, column 15)",
+			want: conv.SpecError{
+				Operation: "POST /users",
+				MediaType: "application/json",
+				Pointer:   "/paths/~1users/post/requestBody/content/application~1json/schema",
+				Line:      13,
+				Column:    15,
+			},
+		},
+		{
+			name: "swagger input has no line",
+			openapi: `swagger: "2.0"
+info:
+  title: Test API
+  version: 1.0.0
+produces:
+  - application/json
+paths:
+  /users:
+    get:
+      summary: List users
+      responses:
+        '200':
+          description: Success
+          schema:
+            type: object`,
+			wantErr: "GET /users 200 response application/json: inline schemas not supported, use $ref " +
+				"(at /paths/~1users/get/responses/200/content/application~1json/schema)",
+			want: conv.SpecError{
+				Operation:  "GET /users",
+				StatusCode: "200",
+				MediaType:  "application/json",
+				Pointer:    "/paths/~1users/get/responses/200/content/application~1json/schema",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := conv.Convert([]byte(test.openapi), conv.ConvertOptions{Title: "Test API"})
+			require.Error(t, err)
+			assert.Equal(t, test.wantErr, err.Error())
+
+			var specErr *conv.SpecError
+			require.True(t, errors.As(err, &specErr))
+			assert.Equal(t, test.want.Operation, specErr.Operation)
+			assert.Equal(t, test.want.StatusCode, specErr.StatusCode)
+			assert.Equal(t, test.want.MediaType, specErr.MediaType)
+			assert.Equal(t, test.want.Pointer, specErr.Pointer)
+			assert.Equal(t, test.want.Line, specErr.Line)
+			assert.Equal(t, test.want.Column, specErr.Column)
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package conv
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Coverage counts how many documentable items have a description and an example
+type Coverage struct {
+	Total     int `json:"total"`
+	Described int `json:"described"`
+	// Examples counts the items with an explicit example, out of ExampleTotal items that can
+	// have one (responses without a JSON body cannot)
+	Examples     int `json:"examples"`
+	ExampleTotal int `json:"exampleTotal"`
+}
+
+// DescribedPercent returns the percentage of items with a description, 0 when there are none
+func (c Coverage) DescribedPercent() float64 {
+	return percent(c.Described, c.Total)
+}
+
+// ExamplesPercent returns the percentage of items with an example, 0 when none can have one
+func (c Coverage) ExamplesPercent() float64 {
+	return percent(c.Examples, c.ExampleTotal)
+}
+
+func (c *Coverage) add(other Coverage) {
+	c.Total += other.Total
+	c.Described += other.Described
+	c.Examples += other.Examples
+	c.ExampleTotal += other.ExampleTotal
+}
+
+// count adds one item that can have an example
+func (c *Coverage) count(described, example bool) {
+	c.Total++
+	c.ExampleTotal++
+	if described {
+		c.Described++
+	}
+	if example {
+		c.Examples++
+	}
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
+
+// CoverageStats is the documentation coverage of operations, parameters, schema fields and responses
+type CoverageStats struct {
+	Operations Coverage `json:"operations"`
+	Parameters Coverage `json:"parameters"`
+	Fields     Coverage `json:"fields"`
+	Responses  Coverage `json:"responses"`
+}
+
+// Stats is the documentation coverage of the documented operations and the schemas they use
+type Stats struct {
+	CoverageStats
+	// ByTag breaks coverage down by operation tag, with untagged operations under "Default APIs".
+	// Fields count the schemas used by the operations of the tag.
+	ByTag map[string]CoverageStats `json:"byTag"`
+	// BySchema holds the field coverage of each component schema used by the operations
+	BySchema map[string]Coverage `json:"bySchema"`
+}
+
+// collectStats measures the documentation coverage of endpoints
+func collectStats(model v3.Document, endpoints []endpoint, opts ConvertOptions) Stats {
+	stats := Stats{
+		ByTag:    make(map[string]CoverageStats),
+		BySchema: make(map[string]Coverage),
+	}
+
+	schemas := make(map[string]bool)
+	tagSchemas := make(map[string]map[string]bool)
+
+	for _, e := range endpoints {
+		op := operationCoverage(e, opts)
+
+		used := make(map[string]bool)
+		collectOperationSchemas(e.operation, used)
+		for name := range used {
+			schemas[name] = true
+		}
+
+		tags := e.tags
+		if len(tags) == 0 {
+			tags = []string{defaultTag}
+		}
+		for _, tag := range tags {
+			tagStats := stats.ByTag[tag]
+			tagStats.Operations.add(op.Operations)
+			tagStats.Parameters.add(op.Parameters)
+			tagStats.Responses.add(op.Responses)
+			stats.ByTag[tag] = tagStats
+
+			if tagSchemas[tag] == nil {
+				tagSchemas[tag] = make(map[string]bool)
+			}
+			for name := range used {
+				tagSchemas[tag][name] = true
+			}
+		}
+
+		stats.Operations.add(op.Operations)
+		stats.Parameters.add(op.Parameters)
+		stats.Responses.add(op.Responses)
+	}
+
+	if model.Components != nil && model.Components.Schemas != nil {
+		for name := range schemas {
+			proxy := model.Components.Schemas.GetOrZero(name)
+			if proxy == nil || proxy.Schema() == nil {
+				continue
+			}
+			var fields Coverage
+			fieldCoverage(proxy.Schema(), opts, &fields)
+			stats.BySchema[name] = fields
+			stats.Fields.add(fields)
+		}
+	}
+
+	for tag, names := range tagSchemas {
+		tagStats := stats.ByTag[tag]
+		for name := range names {
+			tagStats.Fields.add(stats.BySchema[name])
+		}
+		stats.ByTag[tag] = tagStats
+	}
+
+	return stats
+}
+
+// operationCoverage measures the coverage of an operation and its parameters and responses
+func operationCoverage(e endpoint, opts ConvertOptions) CoverageStats {
+	var stats CoverageStats
+	op := e.operation
+	if op == nil {
+		stats.Operations.count(e.summary != "" || e.description != "", false)
+		return stats
+	}
+
+	exampled := false
+	if op.RequestBody != nil && op.RequestBody.Content != nil {
+		if mt := op.RequestBody.Content.GetOrZero("application/json"); mt != nil && hasExample(mt) {
+			exampled = true
+		}
+	}
+
+	for _, param := range op.Parameters {
+		if param == nil || isExcludedAudience(opts, param.Extensions) {
+			continue
+		}
+		example := param.Example != nil || (param.Examples != nil && param.Examples.Len() > 0)
+		if !example && param.Schema != nil {
+			if schema := param.Schema.Schema(); schema != nil {
+				example = schema.Example != nil || len(schema.Examples) > 0
+			}
+		}
+		stats.Parameters.count(param.Description != "", example)
+	}
+
+	if op.Responses != nil && op.Responses.Codes != nil {
+		for pair := op.Responses.Codes.First(); pair != nil; pair = pair.Next() {
+			resp := pair.Value()
+			if resp == nil || isExcludedAudience(opts, resp.Extensions) {
+				continue
+			}
+			stats.Responses.Total++
+			if resp.Description != "" {
+				stats.Responses.Described++
+			}
+			if resp.Content == nil {
+				continue
+			}
+			if mt := resp.Content.GetOrZero("application/json"); mt != nil {
+				stats.Responses.ExampleTotal++
+				if hasExample(mt) {
+					stats.Responses.Examples++
+					exampled = true
+				}
+			}
+		}
+	}
+
+	stats.Operations.count(e.summary != "" || e.description != "", exampled)
+	return stats
+}
+
+// fieldCoverage adds the properties of a schema and of its inline subschemas to coverage.
+// Referenced schemas are measured on their own.
+func fieldCoverage(schema *base.Schema, opts ConvertOptions, coverage *Coverage) {
+	if schema.Properties != nil {
+		for pair := schema.Properties.First(); pair != nil; pair = pair.Next() {
+			proxy := pair.Value()
+			if proxy == nil || proxy.IsReference() {
+				continue
+			}
+			prop := proxy.Schema()
+			if prop == nil || isExcludedAudience(opts, prop.Extensions) {
+				continue
+			}
+
+			coverage.count(prop.Description != "", prop.Example != nil || len(prop.Examples) > 0)
+			fieldCoverage(prop, opts, coverage)
+			if prop.Items != nil && prop.Items.IsA() && !prop.Items.A.IsReference() {
+				if items := prop.Items.A.Schema(); items != nil {
+					fieldCoverage(items, opts, coverage)
+				}
+			}
+		}
+	}
+
+	for _, proxy := range slices.Concat(schema.AllOf, schema.OneOf, schema.AnyOf) {
+		if proxy == nil || proxy.IsReference() {
+			continue
+		}
+		if sub := proxy.Schema(); sub != nil {
+			fieldCoverage(sub, opts, coverage)
+		}
+	}
+}
+
+// collectOperationSchemas adds the names of the component schemas used by an operation's
+// parameters, request body and responses, directly or through other schemas, to names
+func collectOperationSchemas(op *v3.Operation, names map[string]bool) {
+	if op == nil {
+		return
+	}
+
+	for _, param := range op.Parameters {
+		if param != nil {
+			collectSchemaRefs(param.Schema, names)
+		}
+	}
+	if op.RequestBody != nil && op.RequestBody.Content != nil {
+		for pair := op.RequestBody.Content.First(); pair != nil; pair = pair.Next() {
+			collectSchemaRefs(pair.Value().Schema, names)
+		}
+	}
+	if op.Responses != nil && op.Responses.Codes != nil {
+		for pair := op.Responses.Codes.First(); pair != nil; pair = pair.Next() {
+			if resp := pair.Value(); resp != nil && resp.Content != nil {
+				for content := resp.Content.First(); content != nil; content = content.Next() {
+					collectSchemaRefs(content.Value().Schema, names)
+				}
+			}
+		}
+	}
+}
+
+// collectSchemaRefs adds the names of the component schemas reachable from proxy to names
+func collectSchemaRefs(proxy *base.SchemaProxy, names map[string]bool) {
+	if proxy == nil {
+		return
+	}
+	if proxy.IsReference() {
+		ref := proxy.GetReference()
+		if !strings.HasPrefix(ref, schemaRefPrefix) {
+			return
+		}
+		name := strings.TrimPrefix(ref, schemaRefPrefix)
+		if names[name] {
+			return
+		}
+		names[name] = true
+	}
+
+	schema := proxy.Schema()
+	if schema == nil {
+		return
+	}
+	if schema.Properties != nil {
+		for pair := schema.Properties.First(); pair != nil; pair = pair.Next() {
+			collectSchemaRefs(pair.Value(), names)
+		}
+	}
+	if schema.Items != nil && schema.Items.IsA() {
+		collectSchemaRefs(schema.Items.A, names)
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() {
+		collectSchemaRefs(schema.AdditionalProperties.A, names)
+	}
+	for _, sub := range slices.Concat(schema.AllOf, schema.OneOf, schema.AnyOf) {
+		collectSchemaRefs(sub, names)
+	}
+}
@@ -0,0 +1,108 @@
+package conv_test
+
+import (
+	"testing"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertCoverageStats(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      tags: [pets]
+      summary: Get pet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          description: Pet identifier
+          example: pet-1
+          schema:
+            type: string
+        - name: fields
+          in: query
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+              example:
+                name: Rex
+        '404':
+          description: Not found
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+  /health:
+    get:
+      responses:
+        '204':
+          description: Healthy
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          description: Name of the pet
+          example: Rex
+        owner:
+          $ref: '#/components/schemas/Owner'
+        color:
+          type: string
+    Owner:
+      type: object
+      properties:
+        id:
+          type: string
+          description: Owner identifier
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+    Unused:
+      type: object
+      properties:
+        id:
+          type: string`
+
+	result, err := conv.Convert([]byte(spec), conv.ConvertOptions{Title: "Test API", Debug: true})
+	require.NoError(t, err)
+	stats := result.Debug.Stats
+
+	assert.Equal(t, conv.Coverage{Total: 2, Described: 1, Examples: 1, ExampleTotal: 2}, stats.Operations)
+	assert.Equal(t, conv.Coverage{Total: 2, Described: 1, Examples: 1, ExampleTotal: 2}, stats.Parameters)
+	assert.Equal(t, conv.Coverage{Total: 3, Described: 3, Examples: 1, ExampleTotal: 2}, stats.Responses)
+	assert.Equal(t, conv.Coverage{Total: 4, Described: 2, Examples: 1, ExampleTotal: 4}, stats.Fields)
+
+	assert.Equal(t, map[string]conv.Coverage{
+		"Pet":   {Total: 2, Described: 1, Examples: 1, ExampleTotal: 2},
+		"Owner": {Total: 1, Described: 1, ExampleTotal: 1},
+		"Error": {Total: 1, ExampleTotal: 1},
+	}, stats.BySchema)
+
+	require.Contains(t, stats.ByTag, "pets")
+	require.Contains(t, stats.ByTag, "Default APIs")
+	assert.Equal(t, conv.Coverage{Total: 1, Described: 1, Examples: 1, ExampleTotal: 1}, stats.ByTag["pets"].Operations)
+	assert.Equal(t, 4, stats.ByTag["pets"].Fields.Total)
+	assert.Equal(t, conv.Coverage{Total: 1, ExampleTotal: 1}, stats.ByTag["Default APIs"].Operations)
+	assert.Equal(t, conv.Coverage{Total: 1, Described: 1}, stats.ByTag["Default APIs"].Responses)
+
+	assert.Equal(t, 50.0, stats.Operations.DescribedPercent())
+	assert.Equal(t, 50.0, stats.Responses.ExamplesPercent())
+	assert.Equal(t, 0.0, conv.Coverage{}.DescribedPercent())
+}
@@ -0,0 +1,194 @@
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"go.yaml.in/yaml/v4"
+)
+
+// supportedRequestMediaTypes are the request body media types rendered in the documentation
+var supportedRequestMediaTypes = []string{"application/json", "application/x-www-form-urlencoded", "multipart/form-data"}
+
+// strictChecker finds the parts of a spec that the renderer skips, for ConvertOptions.Strict
+type strictChecker struct {
+	model     v3.Document
+	opts      ConvertOptions
+	rewritten bool
+	errs      []error
+	visited   map[string]bool
+	// operation is the operation being checked
+	operation string
+}
+
+// checkStrict returns an error listing every construct of the selected operations, and of the
+// schemas they use, that would be left out of the documentation
+func checkStrict(model v3.Document, endpoints []endpoint, opts ConvertOptions, rewritten bool) error {
+	c := &strictChecker{model: model, opts: opts, rewritten: rewritten, visited: make(map[string]bool)}
+	for _, e := range endpoints {
+		c.checkOperation(e)
+	}
+	return errors.Join(c.errs...)
+}
+
+// fail records a construct at pointer, defined by node, that cannot be rendered
+func (c *strictChecker) fail(node *yaml.Node, pointer, format string, args ...any) {
+	e := &SpecError{Operation: c.operation, Pointer: pointer, Err: fmt.Errorf(format, args...)}
+	if node != nil && !c.rewritten {
+		e.Line, e.Column = node.Line, node.Column
+	}
+	c.errs = append(c.errs, e)
+}
+
+func (c *strictChecker) checkOperation(e endpoint) {
+	pointer := operationPointer(e.method, e.path)
+	op := e.operation
+	if op == nil {
+		return
+	}
+	c.operation = endpointKey(e)
+
+	if c.model.Paths != nil && c.model.Paths.PathItems != nil {
+		if item := c.model.Paths.PathItems.GetOrZero(e.path); item != nil && len(item.Parameters) > 0 {
+			c.fail(item.GoLow().GetRootNode(), "/paths/"+escapePointer(e.path)+"/parameters", "path-level parameters are not supported, declare them on each operation")
+		}
+	}
+
+	for i, param := range op.Parameters {
+		if param == nil || isExcludedAudience(c.opts, param.Extensions) {
+			continue
+		}
+		paramPointer := pointer + "/parameters/" + strconv.Itoa(i)
+		if !slices.Contains([]string{"path", "query", "header"}, param.In) {
+			c.fail(param.GoLow().GetRootNode(), paramPointer, "%s parameter %q is not supported", param.In, param.Name)
+			continue
+		}
+		c.checkSchema(param.Schema, paramPointer+"/schema")
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Content != nil {
+		// Only one form body is rendered, and only when there is no JSON schema to document
+		rendered := "application/json"
+		formType := ""
+		if json := op.RequestBody.Content.GetOrZero("application/json"); json == nil || json.Schema == nil {
+			formType, _ = formRequestSchema(op.RequestBody)
+			rendered = formType
+		}
+
+		for pair := op.RequestBody.Content.First(); pair != nil; pair = pair.Next() {
+			mediaPointer := pointer + "/requestBody/content/" + escapePointer(pair.Key())
+			if !slices.Contains(supportedRequestMediaTypes, pair.Key()) {
+				c.fail(pair.Value().GoLow().GetRootNode(), mediaPointer, "request media type %s is not supported", pair.Key())
+				continue
+			}
+			if pair.Key() != "application/json" && pair.Key() != formType {
+				if rendered == "" {
+					c.fail(pair.Value().GoLow().GetRootNode(), mediaPointer, "request media type %s has no schema to render", pair.Key())
+				} else {
+					c.fail(pair.Value().GoLow().GetRootNode(), mediaPointer, "request media type %s is not rendered alongside %s", pair.Key(), rendered)
+				}
+				continue
+			}
+			c.checkSchema(pair.Value().Schema, mediaPointer+"/schema")
+		}
+	}
+
+	if op.Responses == nil {
+		return
+	}
+	if op.Responses.Default != nil {
+		c.fail(op.Responses.Default.GoLow().GetRootNode(), pointer+"/responses/default", "default responses are not supported")
+	}
+	if op.Responses.Codes == nil {
+		return
+	}
+	for pair := op.Responses.Codes.First(); pair != nil; pair = pair.Next() {
+		resp := pair.Value()
+		if resp == nil || isExcludedAudience(c.opts, resp.Extensions) {
+			continue
+		}
+		respPointer := pointer + "/responses/" + escapePointer(pair.Key())
+		if resp.Headers != nil && resp.Headers.Len() > 0 {
+			c.fail(resp.GoLow().GetRootNode(), respPointer+"/headers", "response headers are not supported")
+		}
+		if resp.Content == nil {
+			continue
+		}
+		for content := resp.Content.First(); content != nil; content = content.Next() {
+			mediaPointer := respPointer + "/content/" + escapePointer(content.Key())
+			if content.Key() != "application/json" {
+				c.fail(content.Value().GoLow().GetRootNode(), mediaPointer, "response media type %s is not supported", content.Key())
+				continue
+			}
+			c.checkSchema(content.Value().Schema, mediaPointer+"/schema")
+		}
+	}
+}
+
+// checkSchema reports references outside components/schemas and schemas that cannot be
+// resolved, following references into component schemas once
+func (c *strictChecker) checkSchema(proxy *base.SchemaProxy, pointer string) {
+	if proxy == nil {
+		return
+	}
+
+	if proxy.IsReference() {
+		ref := proxy.GetReference()
+		name, ok := strings.CutPrefix(ref, schemaRefPrefix)
+		if !ok || strings.Contains(name, "/") {
+			c.fail(proxy.GetValueNode(), pointer, "schema reference %s is not supported, reference a schema in %s", ref, schemaRefPrefix)
+			return
+		}
+		if c.visited[name] {
+			return
+		}
+		c.visited[name] = true
+		pointer = schemaPointer(name)
+	}
+
+	schema := proxy.Schema()
+	if schema == nil {
+		if err := proxy.GetBuildError(); err != nil {
+			c.fail(proxy.GetValueNode(), pointer, "schema cannot be resolved: %v", err)
+		} else {
+			c.fail(proxy.GetValueNode(), pointer, "schema cannot be resolved")
+		}
+		return
+	}
+
+	if schema.Properties != nil {
+		for pair := schema.Properties.First(); pair != nil; pair = pair.Next() {
+			prop := pair.Value()
+			if prop == nil {
+				continue
+			}
+			if !prop.IsReference() {
+				if s := prop.Schema(); s != nil && isExcludedAudience(c.opts, s.Extensions) {
+					continue
+				}
+			}
+			c.checkSchema(prop, pointer+"/properties/"+escapePointer(pair.Key()))
+		}
+	}
+	if schema.Items != nil && schema.Items.IsA() {
+		c.checkSchema(schema.Items.A, pointer+"/items")
+	}
+	// additionalProperties: false only forbids extra properties, so nothing is left out
+	if schema.AdditionalProperties != nil && (schema.AdditionalProperties.IsA() || schema.AdditionalProperties.B) {
+		c.fail(proxy.GetValueNode(), pointer+"/additionalProperties", "additionalProperties is not supported")
+	}
+	for i, sub := range schema.AllOf {
+		c.checkSchema(sub, pointer+"/allOf/"+strconv.Itoa(i))
+	}
+	for i, sub := range schema.OneOf {
+		c.checkSchema(sub, pointer+"/oneOf/"+strconv.Itoa(i))
+	}
+	if len(schema.AnyOf) > 0 {
+		c.fail(proxy.GetValueNode(), pointer+"/anyOf", "anyOf is not supported")
+	}
+}
@@ -0,0 +1,253 @@
+package conv_test
+
+import (
+	"os"
+	"testing"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertStrict(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      summary: Get pet
+      parameters:
+        - name: session
+          in: cookie
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Success
+          headers:
+            X-Rate-Limit:
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+            application/xml:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        default:
+          description: Error
+  /pets:
+    post:
+      summary: Create pet
+      requestBody:
+        content:
+          text/plain:
+            schema:
+              type: string
+      responses:
+        '201':
+          description: Created
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          description: Name of the pet
+        owner:
+          $ref: '#/components/schemas/Owner/properties/name'
+        tag:
+          anyOf:
+            - type: string
+            - type: integer
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string`
+
+	for _, test := range []struct {
+		name    string
+		opts    conv.ConvertOptions
+		wantErr []string
+	}{
+		{
+			name: "lenient by default",
+			opts: conv.ConvertOptions{Title: "Test API"},
+		},
+		{
+			name: "strict",
+			opts: conv.ConvertOptions{Title: "Test API", Strict: true},
+			wantErr: []string{
+				"GET /pets/{id}: path-level parameters are not supported, declare them on each operation (at /paths/~1pets~1{id}/parameters, line 7, column 5)",
+				`GET /pets/{id}: cookie parameter "session" is not supported (at /paths/~1pets~1{id}/get/parameters/0, line 16, column 11)`,
+				"GET /pets/{id}: default responses are not supported (at /paths/~1pets~1{id}/get/responses/default, line 35, column 11)",
+				"GET /pets/{id}: response headers are not supported (at /paths/~1pets~1{id}/get/responses/200/headers, line 22, column 11)",
+				"schema reference #/components/schemas/Owner/properties/name is not supported, reference a schema in #/components/schemas/ (at /components/schemas/Pet/properties/owner, line 65, column 11)",
+				"anyOf is not supported (at /components/schemas/Pet/properties/tag/anyOf, line 58, column 11)",
+				"GET /pets/{id}: response media type application/xml is not supported (at /paths/~1pets~1{id}/get/responses/200/content/application~1xml, line 32, column 15)",
+				"POST /pets: request media type text/plain is not supported (at /paths/~1pets/post/requestBody/content/text~1plain, line 42, column 13)",
+			},
+		},
+		{
+			name: "strict ignores filtered operations",
+			opts: conv.ConvertOptions{Title: "Test API", Strict: true, Include: conv.OperationFilter{Methods: []string{"POST"}}},
+			wantErr: []string{
+				"POST /pets: request media type text/plain is not supported (at /paths/~1pets/post/requestBody/content/text~1plain, line 42, column 13)",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := conv.Convert([]byte(spec), test.opts)
+			if len(test.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, want := range test.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestConvertStrictUnrenderedBodiesAndMaps(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		body    string
+		wantErr []string
+	}{
+		{
+			name: "form alongside json",
+			body: `          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                photo:
+                  type: string
+                  format: binary`,
+			wantErr: []string{
+				"POST /pets: request media type multipart/form-data is not rendered alongside application/json (at /paths/~1pets/post/requestBody/content/multipart~1form-data, line 15, column 13)",
+			},
+		},
+		{
+			name: "second form",
+			body: `          application/x-www-form-urlencoded:
+            schema:
+              $ref: '#/components/schemas/Pet'
+          multipart/form-data:
+            schema:
+              $ref: '#/components/schemas/Pet'`,
+			wantErr: []string{
+				"POST /pets: request media type multipart/form-data is not rendered alongside application/x-www-form-urlencoded (at /paths/~1pets/post/requestBody/content/multipart~1form-data, line 15, column 13)",
+			},
+		},
+		{
+			name: "form without schema",
+			body: `          multipart/form-data: {}`,
+			wantErr: []string{
+				"POST /pets: request media type multipart/form-data has no schema to render (at /paths/~1pets/post/requestBody/content/multipart~1form-data",
+			},
+		},
+		{
+			name: "single form",
+			body: `          multipart/form-data:
+            schema:
+              $ref: '#/components/schemas/Pet'`,
+		},
+		{
+			name: "additionalProperties schema",
+			body: `          application/json:
+            schema:
+              $ref: '#/components/schemas/Labels'`,
+			wantErr: []string{
+				"additionalProperties is not supported (at /components/schemas/Labels/additionalProperties",
+			},
+		},
+		{
+			name: "additionalProperties true",
+			body: `          application/json:
+            schema:
+              $ref: '#/components/schemas/Open'`,
+			wantErr: []string{
+				"additionalProperties is not supported (at /components/schemas/Open/additionalProperties",
+			},
+		},
+		{
+			name: "additionalProperties false",
+			body: `          application/json:
+            schema:
+              $ref: '#/components/schemas/Closed'`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			spec := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      summary: Create pet
+      requestBody:
+        content:
+` + test.body + `
+      responses:
+        '204':
+          description: Created
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          description: Name of the pet
+    Labels:
+      type: object
+      additionalProperties:
+        type: string
+    Open:
+      type: object
+      additionalProperties: true
+    Closed:
+      type: object
+      additionalProperties: false
+      properties:
+        name:
+          type: string
+          description: Name`
+
+			_, err := conv.Convert([]byte(spec), conv.ConvertOptions{Title: "Test API", Strict: true})
+			if len(test.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, want := range test.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestConvertStrictExample(t *testing.T) {
+	openapi, err := os.ReadFile("examples/openapi.yaml")
+	require.NoError(t, err)
+
+	_, err = conv.Convert(openapi, conv.ConvertOptions{Title: "Pet Store API", Strict: true})
+	require.NoError(t, err)
+}
@@ -0,0 +1,587 @@
+package conv
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// defaultSwaggerMediaType is used when a Swagger 2.0 document declares no consumes or produces
+const defaultSwaggerMediaType = "application/json"
+
+// Media types of request bodies built from Swagger 2.0 formData parameters
+const (
+	formURLEncoded = "application/x-www-form-urlencoded"
+	formMultipart  = "multipart/form-data"
+)
+
+// swaggerSchemaKeys are the parameter, header and items fields that move into a schema in OpenAPI 3
+var swaggerSchemaKeys = []string{
+	"type", "format", "items", "enum", "default", "maximum", "exclusiveMaximum", "minimum",
+	"exclusiveMinimum", "maxLength", "minLength", "pattern", "maxItems", "minItems",
+	"uniqueItems", "multipleOf",
+}
+
+// swaggerRefPrefixes maps Swagger 2.0 reference prefixes to their OpenAPI 3 equivalents
+var swaggerRefPrefixes = [][2]string{
+	{"#/definitions/", "#/components/schemas/"},
+	{"#/parameters/", "#/components/parameters/"},
+	{"#/responses/", "#/components/responses/"},
+}
+
+// swaggerUpgrade holds the document-wide settings of a Swagger 2.0 document being upgraded
+type swaggerUpgrade struct {
+	consumes         []string
+	produces         []string
+	globalParameters *yaml.Node
+}
+
+// upgradeSwagger converts a Swagger 2.0 document into the equivalent OpenAPI 3.0 document so it
+// renders with the same layout: definitions become component schemas, consumes/produces become
+// request and response media types, body and formData parameters become request bodies and
+// host/basePath/schemes become servers. Other documents are returned unchanged.
+func upgradeSwagger(spec []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(spec, &doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return spec, nil
+	}
+
+	root := doc.Content[0]
+	version := mappingValue(root, "swagger")
+	if version == nil || !strings.HasPrefix(version.Value, "2.") {
+		return spec, nil
+	}
+
+	u := swaggerUpgrade{
+		consumes:         scalarValues(mappingValue(root, "consumes")),
+		produces:         scalarValues(mappingValue(root, "produces")),
+		globalParameters: mappingValue(root, "parameters"),
+	}
+
+	upgraded := &yaml.Node{Kind: yaml.MappingNode}
+	setValue(upgraded, "openapi", scalarNode("3.0.3"))
+
+	components := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i].Value, root.Content[i+1]
+
+		switch key {
+		case "swagger", "host", "basePath", "schemes", "consumes", "produces":
+		case "info":
+			setValue(upgraded, key, value)
+			if servers := swaggerServers(root); servers != nil {
+				setValue(upgraded, "servers", servers)
+			}
+		case "paths":
+			setValue(upgraded, key, u.paths(value))
+		case "definitions":
+			setValue(components, "schemas", value)
+		case "parameters":
+			setValue(components, "parameters", u.componentParameters(value))
+		case "responses":
+			responses := &yaml.Node{Kind: yaml.MappingNode}
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				setValue(responses, value.Content[j].Value, u.response(value.Content[j+1], u.produces))
+			}
+			setValue(components, "responses", responses)
+		case "securityDefinitions":
+			setValue(components, "securitySchemes", swaggerSecuritySchemes(value))
+		default:
+			setValue(upgraded, key, value)
+		}
+	}
+
+	if len(components.Content) > 0 {
+		setValue(upgraded, "components", components)
+	}
+
+	rewriteSwaggerRefs(upgraded)
+
+	out, err := yaml.Marshal(upgraded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade swagger 2.0 document: %w", err)
+	}
+	return out, nil
+}
+
+// paths upgrades every operation of the paths object
+func (u swaggerUpgrade) paths(paths *yaml.Node) *yaml.Node {
+	if paths.Kind != yaml.MappingNode {
+		return paths
+	}
+
+	upgraded := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		item := paths.Content[i+1]
+		if item.Kind != yaml.MappingNode {
+			setValue(upgraded, paths.Content[i].Value, item)
+			continue
+		}
+
+		// Path-level parameters move into each operation, body and formData ones into its
+		// request body
+		shared := sequenceItems(mappingValue(item, "parameters"))
+		upgradedItem := &yaml.Node{Kind: yaml.MappingNode}
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			key, value := item.Content[j].Value, item.Content[j+1]
+			switch key {
+			case "parameters":
+			case "get", "put", "post", "delete", "options", "head", "patch":
+				setValue(upgradedItem, key, u.operation(value, shared))
+			default:
+				setValue(upgradedItem, key, value)
+			}
+		}
+
+		setValue(upgraded, paths.Content[i].Value, upgradedItem)
+	}
+
+	return upgraded
+}
+
+// operation upgrades an operation, turning body and formData parameters into a request body and
+// response schemas into content for every produced media type
+func (u swaggerUpgrade) operation(op *yaml.Node, shared []*yaml.Node) *yaml.Node {
+	if op.Kind != yaml.MappingNode {
+		return op
+	}
+
+	consumes := u.consumes
+	if value := mappingValue(op, "consumes"); value != nil {
+		consumes = scalarValues(value)
+	}
+	produces := u.produces
+	if value := mappingValue(op, "produces"); value != nil {
+		produces = scalarValues(value)
+	}
+
+	// Operation parameters override path-level ones with the same name and location
+	var merged []*yaml.Node
+	positions := make(map[string]int)
+	for _, param := range slices.Concat(shared, sequenceItems(mappingValue(op, "parameters"))) {
+		key := u.parameterKey(param)
+		if i, ok := positions[key]; ok {
+			merged[i] = param
+			continue
+		}
+		if key != "" {
+			positions[key] = len(merged)
+		}
+		merged = append(merged, param)
+	}
+
+	var body *yaml.Node
+	var form, params []*yaml.Node
+	for _, param := range merged {
+		switch u.parameterLocation(param) {
+		case "body":
+			body = u.resolveParameter(param)
+		case "formData":
+			form = append(form, u.resolveParameter(param))
+		default:
+			params = append(params, param)
+		}
+	}
+
+	upgraded := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(op.Content); i += 2 {
+		key, value := op.Content[i].Value, op.Content[i+1]
+		switch key {
+		case "consumes", "produces", "parameters":
+		case "responses":
+			responses := &yaml.Node{Kind: yaml.MappingNode}
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				setValue(responses, value.Content[j].Value, u.response(value.Content[j+1], produces))
+			}
+			setValue(upgraded, key, responses)
+		default:
+			setValue(upgraded, key, value)
+		}
+	}
+
+	if len(params) > 0 {
+		setValue(upgraded, "parameters", &yaml.Node{Kind: yaml.SequenceNode, Content: u.parameters(params)})
+	}
+	switch {
+	case body != nil:
+		setValue(upgraded, "requestBody", bodyRequestBody(body, consumes))
+	case len(form) > 0:
+		setValue(upgraded, "requestBody", formRequestBody(form, consumes))
+	}
+
+	return upgraded
+}
+
+// parameters upgrades path, query and header parameters, keeping references as they are
+func (u swaggerUpgrade) parameters(params []*yaml.Node) []*yaml.Node {
+	upgraded := make([]*yaml.Node, 0, len(params))
+	for _, param := range params {
+		if mappingValue(param, "$ref") != nil {
+			upgraded = append(upgraded, param)
+			continue
+		}
+		upgraded = append(upgraded, upgradeParameter(param))
+	}
+	return upgraded
+}
+
+// componentParameters upgrades the global parameters. Body and formData parameters are left out
+// because they are inlined into the request bodies of the operations that use them.
+func (u swaggerUpgrade) componentParameters(params *yaml.Node) *yaml.Node {
+	upgraded := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(params.Content); i += 2 {
+		param := params.Content[i+1]
+		if in := u.parameterLocation(param); in == "body" || in == "formData" {
+			continue
+		}
+		setValue(upgraded, params.Content[i].Value, upgradeParameter(param))
+	}
+	return upgraded
+}
+
+// parameterLocation returns the `in` of a parameter, following references to global parameters
+func (u swaggerUpgrade) parameterLocation(param *yaml.Node) string {
+	if in := mappingValue(u.resolveParameter(param), "in"); in != nil {
+		return in.Value
+	}
+	return ""
+}
+
+// parameterKey identifies a parameter by its location and name, following references to global
+// parameters. It is empty when the parameter has no name.
+func (u swaggerUpgrade) parameterKey(param *yaml.Node) string {
+	name := mappingValue(u.resolveParameter(param), "name")
+	if name == nil || name.Value == "" {
+		return ""
+	}
+	return u.parameterLocation(param) + ":" + name.Value
+}
+
+// resolveParameter returns the global parameter a "#/parameters/..." reference points at
+func (u swaggerUpgrade) resolveParameter(param *yaml.Node) *yaml.Node {
+	ref := mappingValue(param, "$ref")
+	if ref == nil || !strings.HasPrefix(ref.Value, "#/parameters/") {
+		return param
+	}
+	if resolved := mappingValue(u.globalParameters, strings.TrimPrefix(ref.Value, "#/parameters/")); resolved != nil {
+		return resolved
+	}
+	return param
+}
+
+// response upgrades a response, describing its schema for every produced media type
+func (u swaggerUpgrade) response(response *yaml.Node, produces []string) *yaml.Node {
+	if response.Kind != yaml.MappingNode || mappingValue(response, "$ref") != nil {
+		return response
+	}
+
+	if len(produces) == 0 {
+		produces = []string{defaultSwaggerMediaType}
+	}
+
+	upgraded := &yaml.Node{Kind: yaml.MappingNode}
+	schema := mappingValue(response, "schema")
+	examples := mappingValue(response, "examples")
+
+	for i := 0; i+1 < len(response.Content); i += 2 {
+		key, value := response.Content[i].Value, response.Content[i+1]
+		switch key {
+		case "schema", "examples":
+		case "headers":
+			headers := &yaml.Node{Kind: yaml.MappingNode}
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				setValue(headers, value.Content[j].Value, upgradeHeader(value.Content[j+1]))
+			}
+			setValue(upgraded, key, headers)
+		default:
+			setValue(upgraded, key, value)
+		}
+	}
+
+	if schema == nil && examples == nil {
+		return upgraded
+	}
+
+	mediaTypes := slices.Clone(produces)
+	for i := 0; examples != nil && i+1 < len(examples.Content); i += 2 {
+		if !slices.Contains(mediaTypes, examples.Content[i].Value) {
+			mediaTypes = append(mediaTypes, examples.Content[i].Value)
+		}
+	}
+
+	content := &yaml.Node{Kind: yaml.MappingNode}
+	for _, mediaType := range mediaTypes {
+		mt := &yaml.Node{Kind: yaml.MappingNode}
+		if schema != nil {
+			setValue(mt, "schema", schema)
+		}
+		if example := mappingValue(examples, mediaType); example != nil {
+			setValue(mt, "example", example)
+		}
+		setValue(content, mediaType, mt)
+	}
+	setValue(upgraded, "content", content)
+
+	return upgraded
+}
+
+// bodyRequestBody builds a request body from a body parameter for every consumed media type
+func bodyRequestBody(param *yaml.Node, consumes []string) *yaml.Node {
+	if len(consumes) == 0 {
+		consumes = []string{defaultSwaggerMediaType}
+	}
+
+	body := &yaml.Node{Kind: yaml.MappingNode}
+	if description := mappingValue(param, "description"); description != nil {
+		setValue(body, "description", description)
+	}
+	if required := mappingValue(param, "required"); required != nil {
+		setValue(body, "required", required)
+	}
+
+	content := &yaml.Node{Kind: yaml.MappingNode}
+	for _, mediaType := range consumes {
+		mt := &yaml.Node{Kind: yaml.MappingNode}
+		if schema := mappingValue(param, "schema"); schema != nil {
+			setValue(mt, "schema", schema)
+		}
+		setValue(content, mediaType, mt)
+	}
+	setValue(body, "content", content)
+
+	return body
+}
+
+// formRequestBody builds a form request body whose schema has a property per formData
+// parameter. It is multipart when the operation consumes multipart or uploads a file.
+func formRequestBody(params []*yaml.Node, consumes []string) *yaml.Node {
+	mediaType := formURLEncoded
+	if slices.Contains(consumes, formMultipart) {
+		mediaType = formMultipart
+	}
+
+	properties := &yaml.Node{Kind: yaml.MappingNode}
+	required := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, param := range params {
+		name := mappingValue(param, "name")
+		if name == nil {
+			continue
+		}
+
+		if typ := mappingValue(param, "type"); typ != nil && typ.Value == "file" {
+			mediaType = formMultipart
+		}
+
+		property := swaggerSchema(param)
+		if description := mappingValue(param, "description"); description != nil {
+			setValue(property, "description", description)
+		}
+		setValue(properties, name.Value, property)
+
+		if value := mappingValue(param, "required"); value != nil && value.Value == "true" {
+			required.Content = append(required.Content, scalarNode(name.Value))
+		}
+	}
+
+	schema := &yaml.Node{Kind: yaml.MappingNode}
+	setValue(schema, "type", scalarNode("object"))
+	setValue(schema, "properties", properties)
+	if len(required.Content) > 0 {
+		setValue(schema, "required", required)
+	}
+
+	mt := &yaml.Node{Kind: yaml.MappingNode}
+	setValue(mt, "schema", schema)
+	content := &yaml.Node{Kind: yaml.MappingNode}
+	setValue(content, mediaType, mt)
+	body := &yaml.Node{Kind: yaml.MappingNode}
+	setValue(body, "content", content)
+
+	return body
+}
+
+// upgradeParameter moves the type information of a path, query or header parameter into a schema
+func upgradeParameter(param *yaml.Node) *yaml.Node {
+	if param.Kind != yaml.MappingNode {
+		return param
+	}
+
+	upgraded := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(param.Content); i += 2 {
+		key, value := param.Content[i].Value, param.Content[i+1]
+		switch {
+		case slices.Contains(swaggerSchemaKeys, key), key == "collectionFormat":
+		case key == "x-example":
+			setValue(upgraded, "example", value)
+		default:
+			setValue(upgraded, key, value)
+		}
+	}
+	setValue(upgraded, "schema", swaggerSchema(param))
+
+	return upgraded
+}
+
+// upgradeHeader moves the type information of a response header into a schema
+func upgradeHeader(header *yaml.Node) *yaml.Node {
+	upgraded := &yaml.Node{Kind: yaml.MappingNode}
+	if description := mappingValue(header, "description"); description != nil {
+		setValue(upgraded, "description", description)
+	}
+	setValue(upgraded, "schema", swaggerSchema(header))
+	return upgraded
+}
+
+// swaggerSchema builds a schema from the type fields of a parameter, header or items object.
+// The Swagger 2.0 file type becomes a binary string.
+func swaggerSchema(node *yaml.Node) *yaml.Node {
+	schema := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch {
+		case key == "type" && value.Value == "file":
+			setValue(schema, "type", scalarNode("string"))
+			setValue(schema, "format", scalarNode("binary"))
+		case key == "items":
+			setValue(schema, key, swaggerSchema(value))
+		case slices.Contains(swaggerSchemaKeys, key):
+			setValue(schema, key, value)
+		}
+	}
+	return schema
+}
+
+// swaggerServers builds the servers list from host, basePath and schemes
+func swaggerServers(root *yaml.Node) *yaml.Node {
+	var host, basePath string
+	if value := mappingValue(root, "host"); value != nil {
+		host = value.Value
+	}
+	if value := mappingValue(root, "basePath"); value != nil {
+		basePath = value.Value
+	}
+	if host == "" && basePath == "" {
+		return nil
+	}
+
+	url := basePath
+	if host != "" {
+		scheme := "https"
+		if schemes := scalarValues(mappingValue(root, "schemes")); len(schemes) > 0 {
+			scheme = schemes[0]
+		}
+		url = scheme + "://" + host + basePath
+	}
+
+	server := &yaml.Node{Kind: yaml.MappingNode}
+	setValue(server, "url", scalarNode(url))
+	return &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{server}}
+}
+
+// swaggerSecuritySchemes upgrades securityDefinitions to security schemes
+func swaggerSecuritySchemes(definitions *yaml.Node) *yaml.Node {
+	flows := map[string]string{
+		"implicit":    "implicit",
+		"password":    "password",
+		"application": "clientCredentials",
+		"accessCode":  "authorizationCode",
+	}
+
+	schemes := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(definitions.Content); i += 2 {
+		definition := definitions.Content[i+1]
+		scheme := &yaml.Node{Kind: yaml.MappingNode}
+
+		typ := mappingValue(definition, "type")
+		switch {
+		case typ != nil && typ.Value == "basic":
+			setValue(scheme, "type", scalarNode("http"))
+			setValue(scheme, "scheme", scalarNode("basic"))
+			if description := mappingValue(definition, "description"); description != nil {
+				setValue(scheme, "description", description)
+			}
+		case typ != nil && typ.Value == "oauth2":
+			flow := &yaml.Node{Kind: yaml.MappingNode}
+			for _, key := range []string{"authorizationUrl", "tokenUrl", "scopes"} {
+				if value := mappingValue(definition, key); value != nil {
+					setValue(flow, key, value)
+				}
+			}
+			flowName := "implicit"
+			if value := mappingValue(definition, "flow"); value != nil && flows[value.Value] != "" {
+				flowName = flows[value.Value]
+			}
+			flowsNode := &yaml.Node{Kind: yaml.MappingNode}
+			setValue(flowsNode, flowName, flow)
+
+			setValue(scheme, "type", typ)
+			if description := mappingValue(definition, "description"); description != nil {
+				setValue(scheme, "description", description)
+			}
+			setValue(scheme, "flows", flowsNode)
+		default:
+			scheme = definition
+		}
+
+		setValue(schemes, definitions.Content[i].Value, scheme)
+	}
+
+	return schemes
+}
+
+// rewriteSwaggerRefs points Swagger 2.0 references at their OpenAPI 3 components and turns
+// string discriminators into discriminator objects
+func rewriteSwaggerRefs(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			switch {
+			case key == "$ref" && value.Kind == yaml.ScalarNode:
+				for _, prefix := range swaggerRefPrefixes {
+					if strings.HasPrefix(value.Value, prefix[0]) {
+						value.Value = prefix[1] + strings.TrimPrefix(value.Value, prefix[0])
+						break
+					}
+				}
+			case key == "discriminator" && value.Kind == yaml.ScalarNode:
+				discriminator := &yaml.Node{Kind: yaml.MappingNode}
+				setValue(discriminator, "propertyName", scalarNode(value.Value))
+				node.Content[i+1] = discriminator
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		rewriteSwaggerRefs(child)
+	}
+}
+
+// setValue adds or replaces key in a mapping node
+func setValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, scalarNode(key), value)
+}
+
+// sequenceItems returns the items of a sequence node, or nil for any other node
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return node.Content
+}
+
+// scalarValues returns the values of a sequence of scalars
+func scalarValues(node *yaml.Node) []string {
+	var values []string
+	for _, item := range sequenceItems(node) {
+		values = append(values, item.Value)
+	}
+	return values
+}
@@ -0,0 +1,212 @@
+package conv_test
+
+import (
+	"testing"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertSwagger(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		swagger       string
+		strict        bool
+		wantSubstr    []string
+		notWantSubstr []string
+	}{
+		{
+			name: "definitions and body parameter",
+			swagger: `swagger: "2.0"
+info:
+  title: Test API
+  version: 1.0.0
+consumes: [application/json]
+produces: [application/json]
+parameters:
+  PetBody:
+    name: pet
+    in: body
+    required: true
+    schema:
+      $ref: '#/definitions/Pet'
+paths:
+  /pets:
+    post:
+      summary: Create pet
+      parameters:
+        - $ref: '#/parameters/PetBody'
+        - name: dryRun
+          in: query
+          type: boolean
+          description: Validate without creating
+      responses:
+        '201':
+          description: Created
+          schema:
+            $ref: '#/definitions/Pet'
+definitions:
+  Pet:
+    type: object
+    required: [name]
+    properties:
+      name:
+        type: string
+        description: Pet name`,
+			wantSubstr: []string{
+				"## POST /pets",
+				"- `dryRun` *(boolean)* Validate without creating",
+				"### Request\n\n```json\n{\n   \"name\":",
+				"- `name` *(string, required)* Pet name",
+				"#### 201 Response",
+			},
+		},
+		{
+			name: "multipart form data",
+			swagger: `swagger: "2.0"
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /photos:
+    post:
+      summary: Upload photo
+      consumes: [multipart/form-data]
+      parameters:
+        - name: file
+          in: formData
+          type: file
+          required: true
+          description: Photo to upload
+        - name: caption
+          in: formData
+          type: string
+          description: Photo caption
+      responses:
+        '204':
+          description: Uploaded`,
+			wantSubstr: []string{
+				"#### Form Parameters\n\nContent type: `multipart/form-data`",
+				"- `file` *(file, required)* Photo to upload",
+				"- `caption` *(string)* Photo caption",
+			},
+		},
+		{
+			name: "url encoded form data",
+			swagger: `swagger: "2.0"
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /login:
+    post:
+      summary: Log in
+      parameters:
+        - name: username
+          in: formData
+          type: string
+          required: true
+        - name: remember
+          in: formData
+          type: boolean
+      responses:
+        '204':
+          description: Logged in`,
+			wantSubstr: []string{
+				"Content type: `application/x-www-form-urlencoded`",
+				"- `username` *(string, required)*",
+				"- `remember` *(boolean)*",
+			},
+		},
+		{
+			name: "path level parameters",
+			swagger: `swagger: "2.0"
+info:
+  title: Test API
+  version: 1.0.0
+host: api.example.com
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        type: string
+        required: true
+        description: Pet ID
+      - name: verbose
+        in: query
+        type: boolean
+        description: Path level
+    get:
+      summary: Get pet
+      parameters:
+        - name: verbose
+          in: query
+          type: boolean
+          description: Operation level
+      responses:
+        '204':
+          description: Found
+    delete:
+      summary: Delete pet
+      responses:
+        '204':
+          description: Deleted`,
+			strict: true,
+			wantSubstr: []string{
+				"## GET /pets/{id}\n\nGet pet\n\n#### Path Parameters\n\n- `id` *(string, required)* Pet ID\n\n" +
+					"#### Query Parameters\n\n- `verbose` *(boolean)* Operation level\n\n",
+				"## DELETE /pets/{id}\n\nDelete pet\n\n#### Path Parameters\n\n- `id` *(string, required)* Pet ID\n\n" +
+					"#### Query Parameters\n\n- `verbose` *(boolean)* Path level\n\n",
+				"curl 'https://api.example.com/pets/<id>'",
+			},
+			notWantSubstr: []string{
+				"/pets/{id}'",
+			},
+		},
+		{
+			name: "produces without json",
+			swagger: `swagger: "2.0"
+info:
+  title: Test API
+  version: 1.0.0
+produces: [application/xml]
+paths:
+  /pets:
+    get:
+      summary: List pets
+      responses:
+        '200':
+          description: Pets
+          schema:
+            $ref: '#/definitions/Pet'
+definitions:
+  Pet:
+    type: object
+    properties:
+      name:
+        type: string`,
+			wantSubstr: []string{
+				"## GET /pets",
+				"#### 200 Response\n\nPets",
+			},
+			notWantSubstr: []string{
+				"```json",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(test.swagger), conv.ConvertOptions{Title: "Test API", Strict: test.strict})
+			require.NoError(t, err)
+
+			md := string(result.Markdown)
+			for _, want := range test.wantSubstr {
+				assert.Contains(t, md, want)
+			}
+			for _, notWant := range test.notWantSubstr {
+				assert.NotContains(t, md, notWant)
+			}
+		})
+	}
+}
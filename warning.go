@@ -0,0 +1,103 @@
+package conv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// WarningCode identifies the kind of problem a warning reports
+type WarningCode string
+
+const (
+	// WarningMissingDescription reports an operation without a summary or description
+	WarningMissingDescription WarningCode = "missing-description"
+	// WarningMissingFieldDescription reports a schema property without a description
+	WarningMissingFieldDescription WarningCode = "missing-field-description"
+	// WarningAnchorCollision reports an operation anchor that had to be suffixed to stay unique
+	WarningAnchorCollision WarningCode = "anchor-collision"
+	// WarningInvalidCodeSamples reports an x-codeSamples extension that is not a list of samples
+	WarningInvalidCodeSamples WarningCode = "invalid-code-samples"
+)
+
+// Warning is a documentation problem found during conversion. Warnings do not stop the
+// conversion unless ConvertOptions.WarningsAsErrors is set.
+type Warning struct {
+	Code    WarningCode
+	Message string
+	// Pointer is a JSON pointer to the part of the spec the warning is about
+	// (e.g. "/components/schemas/Pet/properties/name")
+	Pointer string
+	// Operation is the operation being rendered when the warning was raised (e.g. "GET /pets"),
+	// empty for warnings that are not tied to an operation
+	Operation string
+}
+
+// String formats the warning as "pointer: message"
+func (w Warning) String() string {
+	if w.Pointer == "" {
+		return w.Message
+	}
+	return w.Pointer + ": " + w.Message
+}
+
+// WarningsError is returned by Convert when ConvertOptions.WarningsAsErrors is set and the
+// conversion raised warnings
+type WarningsError struct {
+	Warnings []Warning
+}
+
+func (e *WarningsError) Error() string {
+	messages := make([]string, len(e.Warnings))
+	for i, w := range e.Warnings {
+		messages[i] = w.String()
+	}
+	return fmt.Sprintf("%d warnings treated as errors: %s", len(e.Warnings), strings.Join(messages, "; "))
+}
+
+// warn records a warning about the spec location at pointer, attributed to the operation
+// currently being rendered
+func (r *renderer) warn(code WarningCode, pointer string, format string, args ...any) {
+	r.warnings = append(r.warnings, Warning{
+		Code:      code,
+		Message:   fmt.Sprintf(format, args...),
+		Pointer:   pointer,
+		Operation: r.operation,
+	})
+}
+
+// escapePointer escapes a JSON pointer reference token as described in RFC 6901
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// operationPointer returns the JSON pointer of an operation (e.g. "/paths/~1pets/get")
+func operationPointer(method, path string) string {
+	return "/paths/" + escapePointer(path) + "/" + strings.ToLower(method)
+}
+
+// schemaPointer returns the JSON pointer of a component schema
+func schemaPointer(name string) string {
+	return "/components/schemas/" + escapePointer(name)
+}
+
+// fieldPointer returns the JSON pointer of a property of the component schema at pointer. Fields
+// of inline schemas (empty pointer) are attributed to the operation being rendered.
+func (r *renderer) fieldPointer(pointer, name string) string {
+	if pointer == "" {
+		return r.pointer
+	}
+	return pointer + "/properties/" + escapePointer(name)
+}
+
+// schemaProxyPointer returns the JSON pointer of a referenced component schema, or an empty
+// string for inline schemas
+func schemaProxyPointer(proxy *base.SchemaProxy) string {
+	if proxy != nil && proxy.IsReference() {
+		if name, err := extractSchemaName(proxy.GetReference()); err == nil {
+			return schemaPointer(name)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,126 @@
+package conv_test
+
+import (
+	"errors"
+	"testing"
+
+	conv "github.com/duh-rpc/openapi-markdown.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertWarnings(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    post:
+      summary: Update pet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '204':
+          description: Updated
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          description: Name of the pet
+        color:
+          type: string`
+
+	for _, test := range []struct {
+		name         string
+		opts         conv.ConvertOptions
+		wantWarnings []conv.Warning
+	}{
+		{
+			name: "operation and field warnings",
+			opts: conv.ConvertOptions{Title: "Test API"},
+			wantWarnings: []conv.Warning{
+				{
+					Code:      conv.WarningMissingDescription,
+					Message:   "GET /pets/{id} has no description or summary",
+					Pointer:   "/paths/~1pets~1{id}/get",
+					Operation: "GET /pets/{id}",
+				},
+				{
+					Code:      conv.WarningMissingFieldDescription,
+					Message:   `field "color" is missing a description`,
+					Pointer:   "/components/schemas/Pet/properties/color",
+					Operation: "GET /pets/{id}",
+				},
+				{
+					Code:      conv.WarningMissingFieldDescription,
+					Message:   `field "color" is missing a description`,
+					Pointer:   "/components/schemas/Pet/properties/color",
+					Operation: "POST /pets/{id}",
+				},
+			},
+		},
+		{
+			name: "shared schema warnings",
+			opts: conv.ConvertOptions{Title: "Test API", EnableSharedSchemas: true},
+			wantWarnings: []conv.Warning{
+				{
+					Code:      conv.WarningMissingDescription,
+					Message:   "GET /pets/{id} has no description or summary",
+					Pointer:   "/paths/~1pets~1{id}/get",
+					Operation: "GET /pets/{id}",
+				},
+				{
+					Code:    conv.WarningMissingFieldDescription,
+					Message: `field "color" in schema "Pet" is missing a description`,
+					Pointer: "/components/schemas/Pet/properties/color",
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := conv.Convert([]byte(spec), test.opts)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantWarnings, result.Warnings)
+		})
+	}
+}
+
+func TestConvertWarningsAsErrors(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: Success`
+
+	_, err := conv.Convert([]byte(spec), conv.ConvertOptions{Title: "Test API", WarningsAsErrors: true})
+	require.Error(t, err)
+	assert.Equal(t, "1 warnings treated as errors: /paths/~1pets/get: GET /pets has no description or summary", err.Error())
+
+	var warningsErr *conv.WarningsError
+	require.True(t, errors.As(err, &warningsErr))
+	assert.Equal(t, conv.WarningMissingDescription, warningsErr.Warnings[0].Code)
+
+	result, err := conv.Convert([]byte(spec), conv.ConvertOptions{Title: "Test API"})
+	require.NoError(t, err)
+	assert.Len(t, result.Warnings, 1)
+}